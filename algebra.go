@@ -0,0 +1,203 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"context"
+	"fmt"
+	"runtime/cgo"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Layer algebra                                                   */
+/* -------------------------------------------------------------------- */
+
+// LayerAlgebraOptions controls the layer algebra methods, such as Layer.Intersection
+type LayerAlgebraOptions struct {
+	// Prefix of the result fields copied from the input layer
+	InputPrefix string
+	// Prefix of the result fields copied from the method layer
+	MethodPrefix string
+	// Go on when a feature cannot be inserted or a GEOS call fails, instead of stopping with an error
+	SkipFailures bool
+	// Convert polygons and lines of the result to multipolygons and multilinestrings
+	PromoteToMulti bool
+	// Leave out results of lower dimension than the input, such as the lines where two polygons touch, i.e.
+	// KEEP_LOWER_DIMENSION_GEOMETRIES=NO
+	DropLowerDimensionGeometries bool
+	// Other options of the OGR_L_* layer algebra methods, as KEY=VALUE
+	ExtraOptions []string
+}
+
+// Options returns the options as passed to the OGR_L_* layer algebra methods
+func (opts LayerAlgebraOptions) Options() []string {
+	var options []string
+	if opts.InputPrefix != "" {
+		options = append(options, "INPUT_PREFIX="+opts.InputPrefix)
+	}
+	if opts.MethodPrefix != "" {
+		options = append(options, "METHOD_PREFIX="+opts.MethodPrefix)
+	}
+	if opts.SkipFailures {
+		options = append(options, "SKIP_FAILURES=YES")
+	}
+	if opts.PromoteToMulti {
+		options = append(options, "PROMOTE_TO_MULTI=YES")
+	}
+	if opts.DropLowerDimensionGeometries {
+		options = append(options, "KEEP_LOWER_DIMENSION_GEOMETRIES=NO")
+	}
+	return append(options, opts.ExtraOptions...)
+}
+
+type layerAlgebraFunc func(input, method, result C.OGRLayerH, options **C.char, progress C.GDALProgressFunc, arg unsafe.Pointer) C.OGRErr
+
+// layerAlgebra runs one of the OGR_L_* layer algebra methods with layer as input, stopping when ctx is cancelled
+// or progress returns 0
+func (layer Layer) layerAlgebra(
+	ctx context.Context,
+	operation string,
+	fn layerAlgebraFunc,
+	method, result Layer,
+	opts LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	options := opts.Options()
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	handle := cgo.NewHandle(&callbackProgress{ctx: ctx, fn: progress, data: data})
+	defer handle.Delete()
+
+	var err error
+	cplErr := cplCall(func() {
+		err = OGRErr(fn(
+			layer.cval,
+			method.cval,
+			result.cval,
+			(**C.char)(unsafe.Pointer(&cOptions[0])),
+			C.goGDALProgressHandleProxyB(),
+			C.goGDALHandleArg(C.uintptr_t(handle)),
+		)).Err()
+	})
+	if ctx != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("Error: layer '%s' %s error: %s", layer.Name(), operation, cplErr.msg)
+	}
+	return nil
+}
+
+// Intersection writes to result the areas covered by both the features of this layer and those of method, with
+// the fields of both. When result has no fields, those of both layers are created in it.
+func (layer Layer) Intersection(
+	ctx context.Context,
+	method, result Layer,
+	opts LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.layerAlgebra(ctx, "intersection", func(input, method, result C.OGRLayerH, options **C.char, progress C.GDALProgressFunc, arg unsafe.Pointer) C.OGRErr {
+		return C.OGR_L_Intersection(input, method, result, options, progress, arg)
+	}, method, result, opts, progress, data)
+}
+
+// Union writes to result the areas covered by the features of this layer or those of method, with the fields of
+// both. When result has no fields, those of both layers are created in it.
+func (layer Layer) Union(
+	ctx context.Context,
+	method, result Layer,
+	opts LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.layerAlgebra(ctx, "union", func(input, method, result C.OGRLayerH, options **C.char, progress C.GDALProgressFunc, arg unsafe.Pointer) C.OGRErr {
+		return C.OGR_L_Union(input, method, result, options, progress, arg)
+	}, method, result, opts, progress, data)
+}
+
+// SymDifference writes to result the areas covered by either the features of this layer or those of method but
+// not both, with the fields of both. When result has no fields, those of both layers are created in it.
+func (layer Layer) SymDifference(
+	ctx context.Context,
+	method, result Layer,
+	opts LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.layerAlgebra(ctx, "symmetric difference", func(input, method, result C.OGRLayerH, options **C.char, progress C.GDALProgressFunc, arg unsafe.Pointer) C.OGRErr {
+		return C.OGR_L_SymDifference(input, method, result, options, progress, arg)
+	}, method, result, opts, progress, data)
+}
+
+// Identity writes to result the features of this layer, split where they overlap those of method, with the
+// fields of both. When result has no fields, those of both layers are created in it.
+func (layer Layer) Identity(
+	ctx context.Context,
+	method, result Layer,
+	opts LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.layerAlgebra(ctx, "identity", func(input, method, result C.OGRLayerH, options **C.char, progress C.GDALProgressFunc, arg unsafe.Pointer) C.OGRErr {
+		return C.OGR_L_Identity(input, method, result, options, progress, arg)
+	}, method, result, opts, progress, data)
+}
+
+// Update writes to result the features of this layer with the areas covered by method replaced by the features
+// of method. When result has no fields, those of both layers are created in it.
+func (layer Layer) Update(
+	ctx context.Context,
+	method, result Layer,
+	opts LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.layerAlgebra(ctx, "update", func(input, method, result C.OGRLayerH, options **C.char, progress C.GDALProgressFunc, arg unsafe.Pointer) C.OGRErr {
+		return C.OGR_L_Update(input, method, result, options, progress, arg)
+	}, method, result, opts, progress, data)
+}
+
+// Clip writes to result the parts of the features of this layer covered by those of method, with the fields of
+// this layer only. When result has no fields, those of this layer are created in it.
+func (layer Layer) Clip(
+	ctx context.Context,
+	method, result Layer,
+	opts LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.layerAlgebra(ctx, "clip", func(input, method, result C.OGRLayerH, options **C.char, progress C.GDALProgressFunc, arg unsafe.Pointer) C.OGRErr {
+		return C.OGR_L_Clip(input, method, result, options, progress, arg)
+	}, method, result, opts, progress, data)
+}
+
+// Erase writes to result the parts of the features of this layer not covered by those of method, with the fields
+// of this layer only. When result has no fields, those of this layer are created in it.
+func (layer Layer) Erase(
+	ctx context.Context,
+	method, result Layer,
+	opts LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.layerAlgebra(ctx, "erase", func(input, method, result C.OGRLayerH, options **C.char, progress C.GDALProgressFunc, arg unsafe.Pointer) C.OGRErr {
+		return C.OGR_L_Erase(input, method, result, options, progress, arg)
+	}, method, result, opts, progress, data)
+}
@@ -0,0 +1,63 @@
+package gdal_test
+
+import (
+	"context"
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayerAlgebra(t *testing.T) {
+	ds := testVectorDataset(t)
+	defer ds.Close()
+
+	input := testWKTLayer(t, ds, "input", "POLYGON ((0 0,0 2,2 2,2 0,0 0))")
+	method := testWKTLayer(t, ds, "method", "POLYGON ((1 1,1 3,3 3,3 1,1 1))")
+
+	for _, c := range []struct {
+		name   string
+		run    func(context.Context, gdal.Layer, gdal.Layer, gdal.LayerAlgebraOptions, gdal.ProgressFunc, interface{}) error
+		count  int
+		area   float64
+		fields int
+	}{
+		{"intersection", input.Intersection, 1, 1, 2},
+		{"union", input.Union, 3, 7, 2},
+		{"symdifference", input.SymDifference, 2, 6, 2},
+		{"identity", input.Identity, 2, 4, 2},
+		{"update", input.Update, 2, 7, 1},
+		{"clip", input.Clip, 1, 1, 1},
+		{"erase", input.Erase, 1, 3, 1},
+	} {
+		result, err := ds.CreateLayer(c.name, gdal.SpatialReference{}, gdal.GT_Unknown, nil)
+		assert.NoError(t, err)
+
+		calls := 0
+		progress := func(complete float64, message string, data interface{}) int {
+			calls++
+			return 1
+		}
+		opts := gdal.LayerAlgebraOptions{InputPrefix: "in_", MethodPrefix: "m_", PromoteToMulti: true}
+		assert.NoError(t, c.run(context.Background(), method, result, opts, progress, nil), c.name)
+		assert.Positive(t, calls, c.name)
+
+		count, _ := result.FeatureCount(true)
+		assert.Equal(t, c.count, count, c.name)
+		assert.Equal(t, c.fields, result.Definition().FieldCount(), c.name)
+		area := 0.0
+		for feature, err := range result.Features(context.Background()) {
+			assert.NoError(t, err)
+			assert.Equal(t, gdal.GT_MultiPolygon, feature.Geometry().Type(), c.name)
+			area += feature.Geometry().Area()
+		}
+		assert.InDelta(t, c.area, area, 1e-9, c.name)
+	}
+
+	result, err := ds.CreateLayer("cancelled", gdal.SpatialReference{}, gdal.GT_Unknown, nil)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = input.Intersection(ctx, method, result, gdal.LayerAlgebraOptions{}, nil, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
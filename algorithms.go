@@ -256,11 +256,8 @@ func (src RasterBand) SieveFilter(
 /* --------------------------------------------- */
 
 //Unimplemented: CreateGenImgProjTransformer
-//Unimplemented: CreateGenImgProjTransformer2
 //Unimplemented: CreateGenImgProjTransformer3
 //Unimplemented: SetGenImgProjTransformerDstGeoTransform
-//Unimplemented: DestroyGenImgProjTransformer
-//Unimplemented: GenImgProjTransform
 
 //Unimplemented: CreateReprojectionTransformer
 //Unimplemented: DestroyReprojection
@@ -309,6 +306,64 @@ func ExtractRPCInfoV2(rpcMetadata []string) (RPCInfoV2, error) {
 	return rpc, nil
 }
 
+// RPC DEM interpolation methods accepted by RPCOptions.DEMInterpolation
+const (
+	RPC_DEMINTERP_NEAR     = "near"
+	RPC_DEMINTERP_BILINEAR = "bilinear"
+	RPC_DEMINTERP_CUBIC    = "cubic"
+)
+
+// RPCOptions holds the transformer options understood by GDALCreateRPCTransformerV2 and by gdalwarp -rpc.
+// Zero values are left unset so GDAL defaults apply.
+type RPCOptions struct {
+	Height              float64  // RPC_HEIGHT: constant height above the ellipsoid, used when no DEM is set
+	HeightScale         float64  // RPC_HEIGHT_SCALE: factor applied to heights
+	DEM                 string   // RPC_DEM: path of a DEM providing heights above the ellipsoid
+	DEMInterpolation    string   // RPC_DEMINTERPOLATION: one of the RPC_DEMINTERP_* values
+	DEMMissingValue     *float64 // RPC_DEM_MISSING_VALUE: height to use where the DEM has no data
+	DEMSRS              string   // RPC_DEM_SRS: overrides the DEM vertical SRS
+	DEMApplyVDatumShift *bool    // RPC_DEM_APPLY_VDATUM_SHIFT: whether to apply the DEM vertical datum shift
+	PixelErrorThreshold float64  // RPC_PIXEL_ERROR_THRESHOLD: inverse transform error threshold in pixels
+	MaxIterations       int      // RPC_MAX_ITERATIONS: maximum iterations of the inverse transform
+}
+
+// Options returns the options as KEY=VALUE strings, suitable for CreateRPCTransformer or gdalwarp -to
+func (opts RPCOptions) Options() []string {
+	options := []string{}
+	if opts.Height != 0 {
+		options = append(options, fmt.Sprintf("RPC_HEIGHT=%v", opts.Height))
+	}
+	if opts.HeightScale != 0 {
+		options = append(options, fmt.Sprintf("RPC_HEIGHT_SCALE=%v", opts.HeightScale))
+	}
+	if opts.DEM != "" {
+		options = append(options, "RPC_DEM="+opts.DEM)
+	}
+	if opts.DEMInterpolation != "" {
+		options = append(options, "RPC_DEMINTERPOLATION="+opts.DEMInterpolation)
+	}
+	if opts.DEMMissingValue != nil {
+		options = append(options, fmt.Sprintf("RPC_DEM_MISSING_VALUE=%v", *opts.DEMMissingValue))
+	}
+	if opts.DEMSRS != "" {
+		options = append(options, "RPC_DEM_SRS="+opts.DEMSRS)
+	}
+	if opts.DEMApplyVDatumShift != nil {
+		if *opts.DEMApplyVDatumShift {
+			options = append(options, "RPC_DEM_APPLY_VDATUM_SHIFT=TRUE")
+		} else {
+			options = append(options, "RPC_DEM_APPLY_VDATUM_SHIFT=FALSE")
+		}
+	}
+	if opts.PixelErrorThreshold != 0 {
+		options = append(options, fmt.Sprintf("RPC_PIXEL_ERROR_THRESHOLD=%v", opts.PixelErrorThreshold))
+	}
+	if opts.MaxIterations != 0 {
+		options = append(options, fmt.Sprintf("RPC_MAX_ITERATIONS=%d", opts.MaxIterations))
+	}
+	return options
+}
+
 type RPCTransformer struct {
 	// void*
 	cval unsafe.Pointer
@@ -335,6 +390,11 @@ func CreateRPCTransformer(rpc RPCInfoV2, reversed bool, threshold float64, optio
 	)}
 }
 
+// CreateRPCTransformerWithOptions creates an RPC transformer configured from typed RPCOptions
+func CreateRPCTransformerWithOptions(rpc RPCInfoV2, reversed bool, threshold float64, options RPCOptions) RPCTransformer {
+	return CreateRPCTransformer(rpc, reversed, threshold, options.Options())
+}
+
 func (t RPCTransformer) Destroy() {
 	if t.cval != nil {
 		C.GDALDestroyRPCTransformer(t.cval)
@@ -342,14 +402,20 @@ func (t RPCTransformer) Destroy() {
 }
 
 // Transform a slice of x/y/z points using the RPC transformer. If reversed is true, the inverse transformation is applied.
-// If the transformation fails for any point, an error is returned.
-func (t RPCTransformer) Transform(x, y, z []float64, reversed bool) (xo, yo, zo []float64, err error) {
+// Otherwise points go from destination to source as GDAL defines them: from lat/long to pixel/line for a transformer
+// created with reversed false, and from pixel/line to lat/long for one created with reversed true.
+// ok reports, for each point, whether it was transformed. If the transformation fails for any point, an error listing
+// the failed points is returned along with the partial results.
+func (t RPCTransformer) Transform(x, y, z []float64, reversed bool) (xo, yo, zo []float64, ok []bool, err error) {
 	if t.cval == nil {
-		return nil, nil, nil, fmt.Errorf("RPCTransformer is not initialized")
+		return nil, nil, nil, nil, fmt.Errorf("RPCTransformer is not initialized")
 	}
 	nPoints := len(x)
 	if nPoints != len(y) || nPoints != len(z) {
-		return nil, nil, nil, fmt.Errorf("x, y, z slices must have the same length")
+		return nil, nil, nil, nil, fmt.Errorf("x, y, z slices must have the same length")
+	}
+	if nPoints == 0 {
+		return []float64{}, []float64{}, []float64{}, []bool{}, nil
 	}
 
 	xo = make([]float64, nPoints)
@@ -359,24 +425,26 @@ func (t RPCTransformer) Transform(x, y, z []float64, reversed bool) (xo, yo, zo
 	copy(yo, y)
 	copy(zo, z)
 
-	res := make([]int32, nPoints)
+	res := make([]C.int, nPoints)
 	C.GDALRPCTransform(
 		t.cval,
-		C.int(1),
+		BoolToCInt(!reversed),
 		C.int(nPoints),
 		(*C.double)(&xo[0]),
 		(*C.double)(&yo[0]),
 		(*C.double)(&zo[0]),
-		(*C.int)(unsafe.Pointer(&res[0])),
+		&res[0],
 	)
 
+	ok = make([]bool, nPoints)
 	for i, r := range res {
+		ok[i] = r != 0
 		if r == 0 {
-			err = errors.Join(fmt.Errorf("rpc transform failed for (%f, %f, %f)", x[i], y[i], z[i]), err)
+			err = errors.Join(err, fmt.Errorf("rpc transform failed for (%f, %f, %f)", x[i], y[i], z[i]))
 		}
 	}
 
-	return xo, yo, zo, nil
+	return xo, yo, zo, ok, err
 }
 
 //Unimplemented: DestroyRPCTransformer
@@ -38,11 +38,55 @@ func TestRPCs(t *testing.T) {
 	y := []float64{0.0, 0.0, 3738.0, 3738.0}
 	z := []float64{0.0, 0.0, 0.0, 0.0}
 
-	xo, yo, zo, err := tr.Transform(x, y, z, false)
+	xo, yo, zo, ok, err := tr.Transform(x, y, z, false)
 	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, true, true, true}, ok)
 	assert.InDelta(t, 30.383166198093733, yo[0], 0.000001)
 	assert.InDelta(t, -97.80512372559033, xo[0], 0.000001)
 	assert.InDelta(t, 30.342609346638866, yo[3], 0.000001)
 	assert.InDelta(t, -97.75814680500771, xo[3], 0.000001)
 	assert.Equal(t, 0.0, zo[0])
+
+	xb, yb, _, ok, err := tr.Transform(xo, yo, zo, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, true, true, true}, ok)
+	for i := range x {
+		assert.InDelta(t, x[i], xb[i], 0.5)
+		assert.InDelta(t, y[i], yb[i], 0.5)
+	}
+}
+
+func TestRPCOptions(t *testing.T) {
+	missing := -32768.0
+	opts := gdal.RPCOptions{
+		Height:           100,
+		DEM:              "/vsimem/dem.tif",
+		DEMInterpolation: gdal.RPC_DEMINTERP_CUBIC,
+		DEMMissingValue:  &missing,
+	}
+	assert.Equal(t, []string{
+		"RPC_HEIGHT=100",
+		"RPC_DEM=/vsimem/dem.tif",
+		"RPC_DEMINTERPOLATION=cubic",
+		"RPC_DEM_MISSING_VALUE=-32768",
+	}, opts.Options())
+	assert.Empty(t, gdal.RPCOptions{}.Options())
+
+	rpcs, err := gdal.ExtractRPCInfoV2(testRPCData)
+	assert.NoError(t, err)
+
+	flat := gdal.CreateRPCTransformer(rpcs, true, 0.0, []string{})
+	defer flat.Destroy()
+	raised := gdal.CreateRPCTransformerWithOptions(rpcs, true, 0.0, gdal.RPCOptions{Height: 500})
+	defer raised.Destroy()
+
+	x := []float64{0.0}
+	y := []float64{0.0}
+	z := []float64{0.0}
+	xf, yf, _, _, err := flat.Transform(x, y, z, false)
+	assert.NoError(t, err)
+	xr, yr, _, _, err := raised.Transform(x, y, z, false)
+	assert.NoError(t, err)
+	assert.NotEqual(t, xf[0], xr[0])
+	assert.NotEqual(t, yf[0], yr[0])
 }
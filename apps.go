@@ -12,7 +12,10 @@ package gdal
 */
 import "C"
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"unsafe"
 )
 
@@ -207,3 +210,219 @@ func Rasterize(outputDest string, outputDataset Dataset, inputDataset Dataset, o
 	return Dataset{outputDs}, nil
 
 }
+
+/* --------------------------------------------- */
+/* Multidimensional utilities                    */
+/* --------------------------------------------- */
+
+// MDArraySelection selects an array to copy with MultiDimTranslate
+type MDArraySelection struct {
+	Name      string // full or relative name of the source array
+	DstName   string // name of the output array, defaults to Name
+	Transpose []int  // optional reordering of the dimensions
+	View      string // optional view expression, e.g. "[0,::2]"
+}
+
+// MDSubset restricts a dimension to a range of its indexing variable values. String values must be enclosed in
+// double quotes. If Max is empty a single slice at Min is selected and the dimension is removed.
+type MDSubset struct {
+	Dimension string
+	Min       string
+	Max       string
+}
+
+// MDScaleAxis subsamples a dimension by an integer factor
+type MDScaleAxis struct {
+	Dimension string
+	Factor    int
+}
+
+// MultiDimTranslateOptions are the options of gdalmdimtranslate
+type MultiDimTranslateOptions struct {
+	Format          string             // output driver, e.g. "netCDF", "Zarr", "MEM" or "VRT"
+	Arrays          []MDArraySelection // arrays to copy, all arrays if empty
+	Groups          []string           // groups to copy
+	Subsets         []MDSubset         // dimension subsetting
+	ScaleAxes       []MDScaleAxis      // dimension subsampling
+	CreationOptions []string           // driver creation options, as KEY=VALUE
+	Strict          bool               // fail instead of skipping arrays that cannot be translated
+	ExtraOptions    []string           // additional raw gdalmdimtranslate switches
+}
+
+func (spec MDArraySelection) String() string {
+	s := "name=" + spec.Name
+	if spec.DstName != "" {
+		s += ",dstname=" + spec.DstName
+	}
+	if len(spec.Transpose) > 0 {
+		axes := make([]string, len(spec.Transpose))
+		for i, a := range spec.Transpose {
+			axes[i] = strconv.Itoa(a)
+		}
+		s += ",transpose=[" + strings.Join(axes, ",") + "]"
+	}
+	if spec.View != "" {
+		s += ",view=" + spec.View
+	}
+	return s
+}
+
+// Options converts o into gdalmdimtranslate command line switches
+func (o MultiDimTranslateOptions) Options() []string {
+	var options []string
+	if o.Format != "" {
+		options = append(options, "-of", o.Format)
+	}
+	for _, co := range o.CreationOptions {
+		options = append(options, "-co", co)
+	}
+	for _, a := range o.Arrays {
+		options = append(options, "-array", a.String())
+	}
+	for _, g := range o.Groups {
+		options = append(options, "-group", g)
+	}
+	for _, s := range o.Subsets {
+		if s.Max == "" {
+			options = append(options, "-subset", fmt.Sprintf("%s(%s)", s.Dimension, s.Min))
+		} else {
+			options = append(options, "-subset", fmt.Sprintf("%s(%s,%s)", s.Dimension, s.Min, s.Max))
+		}
+	}
+	if len(o.ScaleAxes) > 0 {
+		axes := make([]string, len(o.ScaleAxes))
+		for i, a := range o.ScaleAxes {
+			axes[i] = fmt.Sprintf("%s(%d)", a.Dimension, a.Factor)
+		}
+		options = append(options, "-scaleaxes", strings.Join(axes, ","))
+	}
+	if o.Strict {
+		options = append(options, "-strict")
+	}
+	return append(options, o.ExtraOptions...)
+}
+
+// MultiDimTranslate converts multidimensional datasets between formats, with optional array selection and
+// subsetting, into a new dataset named destName. GDAL cannot update an existing dataset this way; MEM outputs may
+// have an empty name.
+func MultiDimTranslate(
+	destName string,
+	srcDs []Dataset,
+	options MultiDimTranslateOptions,
+) (Dataset, error) {
+
+	var usageError C.int
+
+	opts := options.Options()
+	length := len(opts)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(opts[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	mdimOptions := C.GDALMultiDimTranslateOptionsNew((**C.char)(unsafe.Pointer(&cOptions[0])), nil)
+	if mdimOptions == nil {
+		return Dataset{}, fmt.Errorf("Error: invalid multidimensional translate options %v", opts)
+	}
+	defer C.GDALMultiDimTranslateOptionsFree(mdimOptions)
+
+	pahSrcDs := make([]C.GDALDatasetH, len(srcDs)+1)
+	for i := 0; i < len(srcDs); i++ {
+		pahSrcDs[i] = srcDs[i].cval
+	}
+
+	cDest := C.CString(destName)
+	defer C.free(unsafe.Pointer(cDest))
+
+	outputDs := C.GDALMultiDimTranslate(
+		cDest,
+		nil,
+		C.int(len(srcDs)),
+		(*C.GDALDatasetH)(unsafe.Pointer(&pahSrcDs[0])),
+		mdimOptions,
+		&usageError,
+	)
+	if outputDs == nil {
+		if usageError != 0 {
+			return Dataset{}, fmt.Errorf("Error: invalid multidimensional translate options %v", opts)
+		}
+		return Dataset{}, ErrFailure
+	}
+	return Dataset{outputDs}, nil
+}
+
+// MDDimensionInfo describes a dimension in MultiDimInfo output
+type MDDimensionInfo struct {
+	Name             string `json:"name"`
+	FullName         string `json:"full_name"`
+	Size             uint64 `json:"size"`
+	Type             string `json:"type,omitempty"`
+	Direction        string `json:"direction,omitempty"`
+	IndexingVariable string `json:"indexing_variable,omitempty"`
+}
+
+// MDSRSInfo describes the spatial reference of an array in MultiDimInfo output
+type MDSRSInfo struct {
+	WKT                      string `json:"wkt"`
+	DataAxisToSRSAxisMapping []int  `json:"data_axis_to_srs_axis_mapping"`
+}
+
+// MDArrayInfo describes an array in MultiDimInfo output
+type MDArrayInfo struct {
+	DataType       interface{}            `json:"datatype"` // a type name, or an object for compound types
+	Dimensions     []string               `json:"dimensions"`
+	DimensionSize  []uint64               `json:"dimension_size"`
+	BlockSize      []uint64               `json:"block_size,omitempty"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty"`
+	Unit           string                 `json:"unit,omitempty"`
+	NoDataValue    interface{}            `json:"nodata_value,omitempty"`
+	Offset         *float64               `json:"offset,omitempty"`
+	Scale          *float64               `json:"scale,omitempty"`
+	SRS            *MDSRSInfo             `json:"srs,omitempty"`
+	StructuralInfo map[string]string      `json:"structural_info,omitempty"`
+	Values         interface{}            `json:"values,omitempty"` // only with the -detailed option
+}
+
+// MDGroupInfo describes a group, and recursively its content, in MultiDimInfo output
+type MDGroupInfo struct {
+	Type           string                 `json:"type"`
+	Driver         string                 `json:"driver,omitempty"`
+	Name           string                 `json:"name"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty"`
+	Dimensions     []MDDimensionInfo      `json:"dimensions,omitempty"`
+	Arrays         map[string]MDArrayInfo `json:"arrays,omitempty"`
+	Groups         map[string]MDGroupInfo `json:"groups,omitempty"`
+	StructuralInfo map[string]string      `json:"structural_info,omitempty"`
+}
+
+// MultiDimInfo reports the structure of a multidimensional dataset, as gdalmdiminfo does
+func MultiDimInfo(ds Dataset, options []string) (MDGroupInfo, error) {
+	var info MDGroupInfo
+
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	infoOptions := C.GDALMultiDimInfoOptionsNew((**C.char)(unsafe.Pointer(&cOptions[0])), nil)
+	if infoOptions == nil {
+		return info, fmt.Errorf("Error: invalid multidimensional info options %v", options)
+	}
+	defer C.GDALMultiDimInfoOptionsFree(infoOptions)
+
+	cInfo := C.GDALMultiDimInfo(ds.cval, infoOptions)
+	if cInfo == nil {
+		return info, ErrFailure
+	}
+	defer C.VSIFree(unsafe.Pointer(cInfo))
+
+	if err := json.Unmarshal([]byte(C.GoString(cInfo)), &info); err != nil {
+		return info, fmt.Errorf("Error: parsing multidimensional info: %w", err)
+	}
+	return info, nil
+}
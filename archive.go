@@ -0,0 +1,207 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Archives                                                        */
+/* -------------------------------------------------------------------- */
+
+// ArchivePath returns the VSI path of the root of a zip, tar or tgz archive, e.g. "/vsizip/data.zip" for
+// "data.zip". Paths already below /vsizip/ or /vsitar/ are returned unchanged.
+func ArchivePath(archive string) (string, error) {
+	if strings.HasPrefix(archive, "/vsizip/") || strings.HasPrefix(archive, "/vsitar/") {
+		return archive, nil
+	}
+	lower := strings.ToLower(archive)
+	switch {
+	case strings.HasSuffix(lower, ".zip"), strings.HasSuffix(lower, ".kmz"):
+		return "/vsizip/" + archive, nil
+	case strings.HasSuffix(lower, ".tar"), strings.HasSuffix(lower, ".tgz"), strings.HasSuffix(lower, ".tar.gz"):
+		return "/vsitar/" + archive, nil
+	}
+	return "", fmt.Errorf("Error: '%s' is not a zip, tar or tgz archive", archive)
+}
+
+// ArchiveEntries lists the files and directories of a zip, tar or tgz archive, with names relative to its root
+func ArchiveEntries(archive string) ([]VSIDirEntry, error) {
+	root, err := ArchivePath(archive)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := VSIOpenDir(root, -1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error: archive '%s' cannot be read", archive)
+	}
+	defer dir.Close()
+
+	var entries []VSIDirEntry
+	for {
+		entry, ok := dir.Next()
+		if !ok {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// sidecarExtensions are auxiliary files that GDAL may open on their own but are never the dataset of interest
+var sidecarExtensions = []string{
+	".aux.xml", ".cpg", ".dbf", ".idx", ".ovr", ".prj", ".qix", ".sbn", ".sbx", ".shx", ".tfw", ".wld", ".xml",
+}
+
+func isSidecar(name string) bool {
+	lower := strings.ToLower(name)
+	for _, ext := range sidecarExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenFromArchive opens, read-only, the first raster or vector dataset found in a zip, tar or tgz archive, in name
+// order. Only files whose path, or base name, matches innerPattern (as path.Match) are tried; an empty pattern
+// matches every file. Auxiliary files such as .dbf, .prj or .aux.xml are skipped.
+func OpenFromArchive(archive, innerPattern string) (Dataset, error) {
+	root, err := ArchivePath(archive)
+	if err != nil {
+		return Dataset{nil}, err
+	}
+	entries, err := ArchiveEntries(archive)
+	if err != nil {
+		return Dataset{nil}, err
+	}
+	for _, entry := range entries {
+		if entry.Mode.IsDir() || isSidecar(entry.Name) {
+			continue
+		}
+		if innerPattern != "" {
+			full, err := path.Match(innerPattern, entry.Name)
+			if err != nil {
+				return Dataset{nil}, fmt.Errorf("Error: invalid pattern '%s': %w", innerPattern, err)
+			}
+			base, _ := path.Match(innerPattern, path.Base(entry.Name))
+			if !full && !base {
+				continue
+			}
+		}
+		ds, err := OpenEx(root+"/"+entry.Name, GDALOFReadOnly|GDALOFRaster|GDALOFVector, nil, nil, nil)
+		if err == nil {
+			return ds, nil
+		}
+	}
+	return Dataset{nil}, fmt.Errorf("Error: no dataset matching '%s' in archive '%s'", innerPattern, archive)
+}
+
+// VSIZipWriter writes a zip archive, possibly to a /vsi path
+type VSIZipWriter struct {
+	cval unsafe.Pointer
+}
+
+// VSICreateZip creates a zip archive. Options are those of CPLCreateZip, e.g. "APPEND=TRUE" to add files to an
+// existing archive. The writer must be closed.
+func VSICreateZip(filename string, options []string) (VSIZipWriter, error) {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	h := C.CPLCreateZip(cFilename, (**C.char)(unsafe.Pointer(&cOptions[0])))
+	if h == nil {
+		return VSIZipWriter{nil}, fmt.Errorf("Error: zip '%s' create error", filename)
+	}
+	return VSIZipWriter{h}, nil
+}
+
+// AddFile adds a file named name to the archive, with the content read from r. Options are those of
+// CPLCreateFileInZip, e.g. "COMPRESSED=NO".
+func (zw VSIZipWriter) AddFile(name string, r io.Reader, options []string) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	if err := CPLErr(C.CPLCreateFileInZip(zw.cval, cName, (**C.char)(unsafe.Pointer(&cOptions[0])))).Err(); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 1<<20)
+	for {
+		n, rerr := r.Read(buf)
+		if n > 0 {
+			if err := CPLErr(C.CPLWriteFileInZip(zw.cval, unsafe.Pointer(&buf[0]), C.int(n))).Err(); err != nil {
+				C.CPLCloseFileInZip(zw.cval)
+				return err
+			}
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			C.CPLCloseFileInZip(zw.cval)
+			return rerr
+		}
+	}
+	return CPLErr(C.CPLCloseFileInZip(zw.cval)).Err()
+}
+
+// AddFileFrom adds the file at srcPath, which may be a /vsi path, to the archive under the name name, using
+// CPLAddFileInZip. Options are those of CPLAddFileInZip, e.g. "SOZIP_ENABLED=YES". It fails on GDAL older than
+// 3.7.
+func (zw VSIZipWriter) AddFileFrom(name, srcPath string, options []string) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	cSrc := C.CString(srcPath)
+	defer C.free(unsafe.Pointer(cSrc))
+
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	return CPLErr(C.goCPLAddFileInZip(zw.cval, cName, cSrc, (**C.char)(unsafe.Pointer(&cOptions[0])))).Err()
+}
+
+// Close finishes writing the archive
+func (zw *VSIZipWriter) Close() error {
+	if zw.cval == nil {
+		return nil
+	}
+	err := CPLErr(C.CPLCloseZip(zw.cval)).Err()
+	zw.cval = nil
+	return err
+}
@@ -0,0 +1,98 @@
+package gdal_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZipArchive(t *testing.T) {
+	ds := testDataset(t)
+	defer ds.Close()
+	driver, err := gdal.GetDriverByName("GTiff")
+	assert.NoError(t, err)
+	tiff, err := driver.CreateCopyToBytes(ds, nil)
+	assert.NoError(t, err)
+
+	src := "/vsimem/test_archive_src.md"
+	writeVSIFile(t, src, []byte("from a vsi path"))
+	defer gdal.VSIUnlink(src)
+
+	name := "/vsimem/test_archive.zip"
+	defer gdal.VSIUnlink(name)
+	zw, err := gdal.VSICreateZip(name, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, zw.AddFile("readme.md", strings.NewReader("not a dataset"), nil))
+	assert.NoError(t, zw.AddFile("data/image.tif", strings.NewReader(string(tiff)), nil))
+	if gdal.VERSION_NUM >= 3070000 {
+		assert.NoError(t, zw.AddFileFrom("data/copy.md", src, nil))
+	} else {
+		assert.Error(t, zw.AddFileFrom("data/copy.md", src, nil))
+		assert.NoError(t, zw.AddFile("data/copy.md", strings.NewReader("from a vsi path"), nil))
+	}
+	assert.NoError(t, zw.Close())
+	assert.NoError(t, zw.Close())
+
+	entries, err := gdal.ArchiveEntries(name)
+	assert.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	assert.Equal(t, []string{"data", "data/copy.md", "data/image.tif", "readme.md"}, names)
+	assert.Equal(t, int64(len(tiff)), entries[2].Size)
+
+	f, err := gdal.VSIFOpenL("/vsizip/"+name+"/data/copy.md", "rb")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("from a vsi path"), gdal.VSIFReadL(1, 100, f))
+	assert.NoError(t, f.Close())
+
+	opened, err := gdal.OpenFromArchive(name, "")
+	assert.NoError(t, err)
+	assert.Equal(t, ds.RasterXSize(), opened.RasterXSize())
+	opened.Close()
+
+	opened, err = gdal.OpenFromArchive(name, "*.tif")
+	assert.NoError(t, err)
+	opened.Close()
+
+	_, err = gdal.OpenFromArchive(name, "*.jp2")
+	assert.Error(t, err)
+	_, err = gdal.OpenFromArchive("/vsimem/test_archive.rar", "")
+	assert.Error(t, err)
+}
+
+func TestTarArchive(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "bundle.tgz")
+	out, err := os.Create(name)
+	assert.NoError(t, err)
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	for _, file := range []struct{ name, body string }{
+		{"b.txt", "bravo"},
+		{"a.txt", "alpha"},
+	} {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.body))}))
+		_, err = tw.Write([]byte(file.body))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+	assert.NoError(t, out.Close())
+
+	entries, err := gdal.ArchiveEntries(name)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "a.txt", entries[0].Name)
+	assert.Equal(t, int64(5), entries[0].Size)
+
+	root, err := gdal.ArchivePath(name)
+	assert.NoError(t, err)
+	assert.Equal(t, "/vsitar/"+name, root)
+}
@@ -0,0 +1,270 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/cdata"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Arrow C stream interface                                        */
+/* -------------------------------------------------------------------- */
+
+// ArrowGeometryEncoding is the encoding of geometry columns in Arrow batches
+type ArrowGeometryEncoding string
+
+const (
+	// Geometries as WKB binary columns, with the ogc.wkb extension name
+	ArrowGeometryWKB = ArrowGeometryEncoding("WKB")
+	// Geometries as native GeoArrow columns, e.g. geoarrow.point
+	ArrowGeometryGeoArrow = ArrowGeometryEncoding("GEOARROW")
+)
+
+// ArrowStreamOptions controls how Layer.ArrowStream exposes a layer
+type ArrowStreamOptions struct {
+	// Encoding of geometry columns, WKB when empty
+	GeometryEncoding ArrowGeometryEncoding
+	// Maximum number of features per batch, GDAL's default of 65424 when 0
+	MaxFeaturesInBatch int
+	// Leave out the feature identifier column
+	OmitFID bool
+	// Other options of OGR_L_GetArrowStream, as KEY=VALUE
+	ExtraOptions []string
+}
+
+// Options returns the options as passed to OGR_L_GetArrowStream
+func (opts ArrowStreamOptions) Options() []string {
+	var options []string
+	if opts.GeometryEncoding != "" {
+		options = append(options, "GEOMETRY_ENCODING="+string(opts.GeometryEncoding))
+	}
+	if opts.MaxFeaturesInBatch > 0 {
+		options = append(options, "MAX_FEATURES_IN_BATCH="+strconv.Itoa(opts.MaxFeaturesInBatch))
+	}
+	if opts.OmitFID {
+		options = append(options, "INCLUDE_FID=NO")
+	}
+	return append(options, opts.ExtraOptions...)
+}
+
+// ArrowStream reads the features of a layer as Arrow record batches. It implements array.RecordReader and must be
+// released before the dataset of the layer is closed.
+type ArrowStream struct {
+	refs   int64
+	stream *C.struct_ArrowArrayStream
+	array  *C.struct_ArrowArray
+	schema *arrow.Schema
+	record arrow.Record
+	err    error
+}
+
+// ArrowStream returns a stream over the features of the layer that pass its filters, from the first one. It
+// requires GDAL 3.6 or later.
+func (layer Layer) ArrowStream(opts ArrowStreamOptions) (*ArrowStream, error) {
+	options := opts.Options()
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	s := &ArrowStream{
+		refs:   1,
+		stream: (*C.struct_ArrowArrayStream)(C.calloc(1, C.sizeof_struct_ArrowArrayStream)),
+		array:  (*C.struct_ArrowArray)(C.calloc(1, C.sizeof_struct_ArrowArray)),
+	}
+	var ok C.bool
+	cplErr := cplCall(func() {
+		ok = C.OGR_L_GetArrowStream(layer.cval, s.stream, (**C.char)(unsafe.Pointer(&cOptions[0])))
+	})
+	if !bool(ok) {
+		s.free()
+		return nil, fmt.Errorf("Error: layer '%s' Arrow stream error: %s", layer.Name(), cplErr.msg)
+	}
+
+	cSchema := (*C.struct_ArrowSchema)(C.calloc(1, C.sizeof_struct_ArrowSchema))
+	defer C.free(unsafe.Pointer(cSchema))
+	if C.goArrowStreamGetSchema(s.stream, cSchema) != 0 {
+		err := s.lastError()
+		s.Release()
+		return nil, err
+	}
+	schema, err := cdata.ImportCArrowSchema((*cdata.CArrowSchema)(unsafe.Pointer(cSchema)))
+	C.goArrowSchemaRelease(cSchema)
+	if err != nil {
+		s.Release()
+		return nil, fmt.Errorf("Error: layer '%s' Arrow schema: %w", layer.Name(), err)
+	}
+	s.schema = schema
+	return s, nil
+}
+
+func (s *ArrowStream) lastError() error {
+	msg := C.goArrowStreamGetLastError(s.stream)
+	if msg == nil {
+		return fmt.Errorf("Error: Arrow stream read error")
+	}
+	return fmt.Errorf("Error: Arrow stream read error: %s", C.GoString(msg))
+}
+
+func (s *ArrowStream) free() {
+	C.free(unsafe.Pointer(s.stream))
+	C.free(unsafe.Pointer(s.array))
+	s.stream = nil
+	s.array = nil
+}
+
+// Schema returns the schema of the batches
+func (s *ArrowStream) Schema() *arrow.Schema {
+	return s.schema
+}
+
+// Next reads the next batch, returning false at the end of the stream or on error
+func (s *ArrowStream) Next() bool {
+	if s.record != nil {
+		s.record.Release()
+		s.record = nil
+	}
+	if s.stream == nil || s.err != nil {
+		return false
+	}
+	if C.goArrowStreamGetNext(s.stream, s.array) != 0 {
+		s.err = s.lastError()
+		return false
+	}
+	if s.array.release == nil {
+		return false
+	}
+	record, err := cdata.ImportCRecordBatchWithSchema((*cdata.CArrowArray)(unsafe.Pointer(s.array)), s.schema)
+	if err != nil {
+		cdata.ReleaseCArrowArray((*cdata.CArrowArray)(unsafe.Pointer(s.array)))
+		s.err = err
+		return false
+	}
+	s.record = record
+	return true
+}
+
+// Record returns the batch read by the last call to Next. It is only valid until the next call to Next, unless
+// retained.
+func (s *ArrowStream) Record() arrow.Record {
+	return s.record
+}
+
+// Err returns the error that stopped Next, if any
+func (s *ArrowStream) Err() error {
+	return s.err
+}
+
+// Retain adds a reference to the stream
+func (s *ArrowStream) Retain() {
+	atomic.AddInt64(&s.refs, 1)
+}
+
+// Release removes a reference to the stream, releasing it along with its current batch once there are none left
+func (s *ArrowStream) Release() {
+	if atomic.AddInt64(&s.refs, -1) != 0 || s.stream == nil {
+		return
+	}
+	if s.record != nil {
+		s.record.Release()
+		s.record = nil
+	}
+	C.goArrowStreamRelease(s.stream)
+	s.free()
+}
+
+// isArrowGeometry reports whether an Arrow field holds geometries, from its extension name
+func isArrowGeometry(field arrow.Field) bool {
+	name, ok := field.Metadata.GetValue("ARROW:extension:name")
+	if ext, isExt := field.Type.(arrow.ExtensionType); isExt {
+		name, ok = ext.ExtensionName(), true
+	}
+	return ok && (name == "ogc.wkb" || strings.HasPrefix(name, "geoarrow."))
+}
+
+// CreateFieldsFromArrowSchema creates a field in the layer for each column of schema, except geometry columns,
+// which are recognized by their ogc.wkb or geoarrow extension name. Feature identifier columns should be left
+// out of schema. It fails on GDAL older than 3.8.
+func (layer Layer) CreateFieldsFromArrowSchema(schema *arrow.Schema, options []string) error {
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	cSchema := (*C.struct_ArrowSchema)(C.calloc(1, C.sizeof_struct_ArrowSchema))
+	defer C.free(unsafe.Pointer(cSchema))
+
+	for _, field := range schema.Fields() {
+		if isArrowGeometry(field) {
+			continue
+		}
+		*cSchema = C.struct_ArrowSchema{}
+		cdata.ExportArrowSchema(arrow.NewSchema([]arrow.Field{field}, nil), (*cdata.CArrowSchema)(unsafe.Pointer(cSchema)))
+		var ok C.int
+		cplErr := cplCall(func() {
+			ok = C.goOGR_L_CreateFieldFromArrowSchema(layer.cval, *cSchema.children, (**C.char)(unsafe.Pointer(&cOptions[0])))
+		})
+		C.goArrowSchemaRelease(cSchema)
+		if ok == 0 {
+			return fmt.Errorf("Error: field '%s' create error: %s", field.Name, cplErr.msg)
+		}
+	}
+	return nil
+}
+
+// WriteArrowBatch creates one feature in the layer for each row of record, whose columns are matched to the layer
+// fields by name. Geometry columns must be WKB or GeoArrow with an extension name. Options are those of
+// OGR_L_WriteArrowBatch, e.g. "FID=id" to use a column as feature identifiers. It fails on GDAL older than 3.8.
+func (layer Layer) WriteArrowBatch(record arrow.Record, options []string) error {
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	cSchema := (*C.struct_ArrowSchema)(C.calloc(1, C.sizeof_struct_ArrowSchema))
+	defer C.free(unsafe.Pointer(cSchema))
+	cArray := (*C.struct_ArrowArray)(C.calloc(1, C.sizeof_struct_ArrowArray))
+	defer C.free(unsafe.Pointer(cArray))
+
+	cdata.ExportArrowRecordBatch(
+		record,
+		(*cdata.CArrowArray)(unsafe.Pointer(cArray)),
+		(*cdata.CArrowSchema)(unsafe.Pointer(cSchema)),
+	)
+	// the array may have been moved by GDAL, in which case releasing it does nothing
+	defer cdata.ReleaseCArrowArray((*cdata.CArrowArray)(unsafe.Pointer(cArray)))
+	defer C.goArrowSchemaRelease(cSchema)
+
+	var ok C.int
+	cplErr := cplCall(func() {
+		ok = C.goOGR_L_WriteArrowBatch(layer.cval, cSchema, cArray, (**C.char)(unsafe.Pointer(&cOptions[0])))
+	})
+	if ok == 0 {
+		return fmt.Errorf("Error: layer '%s' Arrow batch write error: %s", layer.Name(), cplErr.msg)
+	}
+	return nil
+}
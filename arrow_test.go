@@ -0,0 +1,67 @@
+package gdal_test
+
+import (
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+type testArrowRow struct {
+	Name     string        `ogr:"name"`
+	Value    int64         `ogr:"value"`
+	Location gdal.Geometry `ogr:",geometry"`
+}
+
+func TestArrowStream(t *testing.T) {
+	ds := testVectorDataset(t)
+	defer ds.Close()
+
+	src, err := gdal.CreateLayerFromStruct[testArrowRow](ds, "src", gdal.SpatialReference{})
+	assert.NoError(t, err)
+	var rows []testArrowRow
+	for i := 0; i < 10; i++ {
+		p := gdal.Create(gdal.GT_Point)
+		p.AddPoint2D(float64(i), float64(-i))
+		defer p.Destroy()
+		rows = append(rows, testArrowRow{Name: string(rune('a' + i)), Value: int64(i * 10), Location: p})
+	}
+	assert.NoError(t, src.WriteAll(rows))
+
+	stream, err := src.ArrowStream(gdal.ArrowStreamOptions{MaxFeaturesInBatch: 4, OmitFID: true})
+	assert.NoError(t, err)
+	defer stream.Release()
+	assert.Equal(t, []string{"name", "value", "wkb_geometry"}, func() []string {
+		var names []string
+		for _, f := range stream.Schema().Fields() {
+			names = append(names, f.Name)
+		}
+		return names
+	}())
+
+	skipBeforeGDAL(t, 3, 8)
+	dst, err := ds.CreateLayer("dst", gdal.SpatialReference{}, gdal.GT_Point, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, dst.CreateFieldsFromArrowSchema(stream.Schema(), nil))
+	assert.Equal(t, 2, dst.Definition().FieldCount())
+
+	batches := 0
+	for stream.Next() {
+		record := stream.Record()
+		assert.LessOrEqual(t, record.NumRows(), int64(4))
+		assert.NoError(t, dst.WriteArrowBatch(record, nil))
+		batches++
+	}
+	assert.NoError(t, stream.Err())
+	assert.Equal(t, 3, batches)
+
+	var copied []testArrowRow
+	assert.NoError(t, dst.ReadAll(&copied))
+	assert.Len(t, copied, 10)
+	for i, row := range copied {
+		assert.Equal(t, rows[i].Name, row.Name)
+		assert.Equal(t, rows[i].Value, row.Value)
+		assert.True(t, rows[i].Location.Equals(row.Location))
+		row.Location.Destroy()
+	}
+}
@@ -0,0 +1,256 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Cloud storage configuration                                     */
+/* -------------------------------------------------------------------- */
+
+// cloudOption is a config key and its value, skipped when the value is empty
+type cloudOption struct {
+	key   string
+	value string
+}
+
+func yesNo(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	if *b {
+		return "YES"
+	}
+	return "NO"
+}
+
+func retryOptions(maxRetry int, retryDelay float64) []cloudOption {
+	var options []cloudOption
+	if maxRetry > 0 {
+		options = append(options, cloudOption{"GDAL_HTTP_MAX_RETRY", strconv.Itoa(maxRetry)})
+	}
+	if retryDelay > 0 {
+		options = append(options, cloudOption{"GDAL_HTTP_RETRY_DELAY", strconv.FormatFloat(retryDelay, 'f', -1, 64)})
+	}
+	return options
+}
+
+// applyCloudOptions sets options on prefix, which must be below one of the file systems in fsPrefixes
+func applyCloudOptions(prefix string, fsPrefixes []string, options []cloudOption) error {
+	ok := false
+	for _, p := range fsPrefixes {
+		ok = ok || strings.HasPrefix(prefix, p)
+	}
+	if !ok {
+		return fmt.Errorf("Error: prefix '%s' is not below %s", prefix, strings.Join(fsPrefixes, " or "))
+	}
+	VSIClearPathSpecificOption(prefix)
+	for _, o := range options {
+		if o.value != "" {
+			VSISetPathSpecificOption(prefix, o.key, o.value)
+		}
+	}
+	VSICurlPartialClearCache(prefix)
+	return nil
+}
+
+func clearCloudOptions(prefixes []string) {
+	for _, prefix := range prefixes {
+		VSIClearPathSpecificOption(prefix)
+		VSIClearCredentials(prefix)
+		VSICurlPartialClearCache(prefix)
+	}
+}
+
+func appendPrefix(prefixes []string, prefix string) []string {
+	for _, p := range prefixes {
+		if p == prefix {
+			return prefixes
+		}
+	}
+	return append(prefixes, prefix)
+}
+
+// S3Config holds the /vsis3/ options applied to a path prefix. Empty fields keep GDAL's defaults.
+type S3Config struct {
+	Endpoint        string  // AWS_S3_ENDPOINT, host[:port] of an S3 compatible server
+	Region          string  // AWS_REGION
+	AccessKeyID     string  // AWS_ACCESS_KEY_ID
+	SecretAccessKey string  // AWS_SECRET_ACCESS_KEY
+	SessionToken    string  // AWS_SESSION_TOKEN
+	Profile         string  // AWS_PROFILE
+	NoSignRequest   bool    // AWS_NO_SIGN_REQUEST, for public buckets
+	VirtualHosting  *bool   // AWS_VIRTUAL_HOSTING, bucket as a sub-domain rather than in the path
+	HTTPS           *bool   // AWS_HTTPS
+	RequestPayer    string  // AWS_REQUEST_PAYER, "requester" for requester-pays buckets
+	MaxRetry        int     // GDAL_HTTP_MAX_RETRY
+	RetryDelay      float64 // GDAL_HTTP_RETRY_DELAY, in seconds
+
+	applied []string
+}
+
+func (c S3Config) options() []cloudOption {
+	options := []cloudOption{
+		{"AWS_S3_ENDPOINT", c.Endpoint},
+		{"AWS_REGION", c.Region},
+		{"AWS_ACCESS_KEY_ID", c.AccessKeyID},
+		{"AWS_SECRET_ACCESS_KEY", c.SecretAccessKey},
+		{"AWS_SESSION_TOKEN", c.SessionToken},
+		{"AWS_PROFILE", c.Profile},
+		{"AWS_VIRTUAL_HOSTING", yesNo(c.VirtualHosting)},
+		{"AWS_HTTPS", yesNo(c.HTTPS)},
+		{"AWS_REQUEST_PAYER", c.RequestPayer},
+	}
+	if c.NoSignRequest {
+		options = append(options, cloudOption{"AWS_NO_SIGN_REQUEST", "YES"})
+	}
+	return append(options, retryOptions(c.MaxRetry, c.RetryDelay)...)
+}
+
+// Apply sets the options on prefix, e.g. "/vsis3/bucket/", replacing options previously set on it
+func (c *S3Config) Apply(prefix string) error {
+	if err := applyCloudOptions(prefix, []string{"/vsis3/", "/vsis3_streaming/"}, c.options()); err != nil {
+		return err
+	}
+	c.applied = appendPrefix(c.applied, prefix)
+	return nil
+}
+
+// Clear removes the options and cached credentials from every prefix the config was applied to
+func (c *S3Config) Clear() {
+	clearCloudOptions(c.applied)
+	c.applied = nil
+}
+
+// GCSConfig holds the /vsigs/ options applied to a path prefix. Empty fields keep GDAL's defaults.
+type GCSConfig struct {
+	Endpoint               string  // CPL_GS_ENDPOINT, e.g. "http://localhost:4443/"
+	AccessKeyID            string  // GS_ACCESS_KEY_ID, HMAC key
+	SecretAccessKey        string  // GS_SECRET_ACCESS_KEY, HMAC secret
+	ApplicationCredentials string  // GOOGLE_APPLICATION_CREDENTIALS, path to a service account JSON file
+	OAuth2RefreshToken     string  // GS_OAUTH2_REFRESH_TOKEN
+	OAuth2ClientID         string  // GS_OAUTH2_CLIENT_ID
+	OAuth2ClientSecret     string  // GS_OAUTH2_CLIENT_SECRET
+	NoSignRequest          bool    // GS_NO_SIGN_REQUEST, for public buckets
+	UserProject            string  // GS_USER_PROJECT, project billed for requester-pays buckets
+	MaxRetry               int     // GDAL_HTTP_MAX_RETRY
+	RetryDelay             float64 // GDAL_HTTP_RETRY_DELAY, in seconds
+
+	applied []string
+}
+
+func (c GCSConfig) options() []cloudOption {
+	options := []cloudOption{
+		{"CPL_GS_ENDPOINT", c.Endpoint},
+		{"GS_ACCESS_KEY_ID", c.AccessKeyID},
+		{"GS_SECRET_ACCESS_KEY", c.SecretAccessKey},
+		{"GOOGLE_APPLICATION_CREDENTIALS", c.ApplicationCredentials},
+		{"GS_OAUTH2_REFRESH_TOKEN", c.OAuth2RefreshToken},
+		{"GS_OAUTH2_CLIENT_ID", c.OAuth2ClientID},
+		{"GS_OAUTH2_CLIENT_SECRET", c.OAuth2ClientSecret},
+		{"GS_USER_PROJECT", c.UserProject},
+	}
+	if c.NoSignRequest {
+		options = append(options, cloudOption{"GS_NO_SIGN_REQUEST", "YES"})
+	}
+	return append(options, retryOptions(c.MaxRetry, c.RetryDelay)...)
+}
+
+// Apply sets the options on prefix, e.g. "/vsigs/bucket/", replacing options previously set on it
+func (c *GCSConfig) Apply(prefix string) error {
+	if err := applyCloudOptions(prefix, []string{"/vsigs/", "/vsigs_streaming/"}, c.options()); err != nil {
+		return err
+	}
+	c.applied = appendPrefix(c.applied, prefix)
+	return nil
+}
+
+// Clear removes the options and cached credentials from every prefix the config was applied to
+func (c *GCSConfig) Clear() {
+	clearCloudOptions(c.applied)
+	c.applied = nil
+}
+
+// AzureConfig holds the /vsiaz/ options applied to a path prefix. Empty fields keep GDAL's defaults.
+type AzureConfig struct {
+	ConnectionString string  // AZURE_STORAGE_CONNECTION_STRING, takes precedence over the fields below
+	Account          string  // AZURE_STORAGE_ACCOUNT
+	AccessKey        string  // AZURE_STORAGE_ACCESS_KEY
+	SASToken         string  // AZURE_STORAGE_SAS_TOKEN
+	Endpoint         string  // CPL_AZURE_ENDPOINT, e.g. "localhost:10000" for Azurite
+	NoSignRequest    bool    // AZURE_NO_SIGN_REQUEST, for public containers
+	VirtualHosting   *bool   // CPL_AZURE_VIRTUAL_HOSTING
+	HTTPS            *bool   // CPL_AZURE_USE_HTTPS
+	MaxRetry         int     // GDAL_HTTP_MAX_RETRY
+	RetryDelay       float64 // GDAL_HTTP_RETRY_DELAY, in seconds
+
+	applied []string
+}
+
+func (c AzureConfig) options() []cloudOption {
+	options := []cloudOption{
+		{"AZURE_STORAGE_CONNECTION_STRING", c.ConnectionString},
+		{"AZURE_STORAGE_ACCOUNT", c.Account},
+		{"AZURE_STORAGE_ACCESS_KEY", c.AccessKey},
+		{"AZURE_STORAGE_SAS_TOKEN", c.SASToken},
+		{"CPL_AZURE_ENDPOINT", c.Endpoint},
+		{"CPL_AZURE_VIRTUAL_HOSTING", yesNo(c.VirtualHosting)},
+		{"CPL_AZURE_USE_HTTPS", yesNo(c.HTTPS)},
+	}
+	if c.NoSignRequest {
+		options = append(options, cloudOption{"AZURE_NO_SIGN_REQUEST", "YES"})
+	}
+	return append(options, retryOptions(c.MaxRetry, c.RetryDelay)...)
+}
+
+// Apply sets the options on prefix, e.g. "/vsiaz/container/", replacing options previously set on it
+func (c *AzureConfig) Apply(prefix string) error {
+	if err := applyCloudOptions(prefix, []string{"/vsiaz/", "/vsiaz_streaming/", "/vsiadls/"}, c.options()); err != nil {
+		return err
+	}
+	c.applied = appendPrefix(c.applied, prefix)
+	return nil
+}
+
+// Clear removes the options and cached credentials from every prefix the config was applied to
+func (c *AzureConfig) Clear() {
+	clearCloudOptions(c.applied)
+	c.applied = nil
+}
+
+// VSIClearCredentials drops the credentials and options set for paths below prefix, or for all paths if prefix is
+// empty
+func VSIClearCredentials(prefix string) {
+	if prefix == "" {
+		C.VSIClearCredentials(nil)
+		return
+	}
+	cPrefix := C.CString(prefix)
+	defer C.free(unsafe.Pointer(cPrefix))
+	C.VSIClearCredentials(cPrefix)
+}
+
+// VSICurlClearCache drops all cached content, sizes and directory listings of network file systems
+func VSICurlClearCache() {
+	C.VSICurlClearCache()
+}
+
+// VSICurlPartialClearCache drops the cached content, sizes and directory listings of paths below prefix
+func VSICurlPartialClearCache(prefix string) {
+	cPrefix := C.CString(prefix)
+	defer C.free(unsafe.Pointer(cPrefix))
+	C.VSICurlPartialClearCache(cPrefix)
+}
@@ -0,0 +1,93 @@
+package gdal_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3Config(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string][]byte{"/bucket/data.txt": []byte("hello s3")}
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		authorization = r.Header.Get("Authorization")
+		data, ok := objects[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, r.URL.Path, time.Unix(0, 0), bytes.NewReader(data))
+	}))
+	defer server.Close()
+
+	no := false
+	config := gdal.S3Config{
+		Endpoint:        strings.TrimPrefix(server.URL, "http://"),
+		Region:          "us-east-1",
+		AccessKeyID:     "AKIDTEST",
+		SecretAccessKey: "secret",
+		HTTPS:           &no,
+		VirtualHosting:  &no,
+		MaxRetry:        1,
+	}
+	assert.Error(t, config.Apply("/vsigs/bucket/"))
+	assert.NoError(t, config.Apply("/vsis3/bucket/"))
+	defer config.Clear()
+	assert.Equal(t, "AKIDTEST", gdal.VSIGetPathSpecificOption("/vsis3/bucket/data.txt", "AWS_ACCESS_KEY_ID", ""))
+
+	f, err := gdal.VSIFOpenL("/vsis3/bucket/data.txt", "rb")
+	assert.NoError(t, err)
+	data, err := io.ReadAll(f)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	assert.Equal(t, "hello s3", string(data))
+	mu.Lock()
+	assert.Contains(t, authorization, "AKIDTEST")
+	objects["/bucket/data.txt"] = []byte("hello again s3")
+	mu.Unlock()
+
+	stat, err := gdal.VSIStat("/vsis3/bucket/data.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), stat.Size)
+	gdal.VSICurlPartialClearCache("/vsis3/bucket/")
+	stat, err = gdal.VSIStat("/vsis3/bucket/data.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(14), stat.Size)
+
+	_, err = gdal.VSIStat("/vsis3/bucket/missing.txt")
+	assert.Error(t, err)
+
+	config.Clear()
+	assert.Equal(t, "", gdal.VSIGetPathSpecificOption("/vsis3/bucket/data.txt", "AWS_ACCESS_KEY_ID", ""))
+}
+
+func TestCloudConfigPrefixes(t *testing.T) {
+	gcs := gdal.GCSConfig{UserProject: "billing", NoSignRequest: true}
+	assert.Error(t, gcs.Apply("/vsis3/bucket/"))
+	assert.NoError(t, gcs.Apply("/vsigs/bucket/"))
+	assert.Equal(t, "billing", gdal.VSIGetPathSpecificOption("/vsigs/bucket/key", "GS_USER_PROJECT", ""))
+	assert.Equal(t, "YES", gdal.VSIGetPathSpecificOption("/vsigs/bucket/key", "GS_NO_SIGN_REQUEST", ""))
+	gcs.Clear()
+	assert.Equal(t, "", gdal.VSIGetPathSpecificOption("/vsigs/bucket/key", "GS_USER_PROJECT", ""))
+
+	az := gdal.AzureConfig{Account: "devstoreaccount1", SASToken: "sv=1"}
+	assert.Error(t, az.Apply("/vsigs/container/"))
+	assert.NoError(t, az.Apply("/vsiaz/container/"))
+	assert.Equal(t, "devstoreaccount1", gdal.VSIGetPathSpecificOption("/vsiaz/container/blob", "AZURE_STORAGE_ACCOUNT", ""))
+	az.Clear()
+	assert.Equal(t, "", gdal.VSIGetPathSpecificOption("/vsiaz/container/blob", "AZURE_STORAGE_ACCOUNT", ""))
+
+	gdal.VSIClearCredentials("")
+	gdal.VSICurlClearCache()
+}
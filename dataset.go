@@ -13,6 +13,9 @@ import "C"
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"unsafe"
 )
 
@@ -39,10 +42,97 @@ func (dataset Dataset) FileList() []string {
 	return strings
 }
 
+// Subdataset describes a child dataset advertised in the SUBDATASETS metadata domain
+type Subdataset struct {
+	Name        string // connection string to pass to Open
+	Description string
+}
+
+// Subdatasets lists the subdatasets of a container dataset (NetCDF, HDF5, GeoPackage, multi-page TIFF...)
+func (dataset Dataset) Subdatasets() []Subdataset {
+	byIndex := map[int]*Subdataset{}
+	for _, item := range dataset.Metadata("SUBDATASETS") {
+		key, value, found := strings.Cut(item, "=")
+		if !found || !strings.HasPrefix(key, "SUBDATASET_") {
+			continue
+		}
+		num, field, found := strings.Cut(strings.TrimPrefix(key, "SUBDATASET_"), "_")
+		if !found {
+			continue
+		}
+		index, err := strconv.Atoi(num)
+		if err != nil {
+			continue
+		}
+		sd, ok := byIndex[index]
+		if !ok {
+			sd = &Subdataset{}
+			byIndex[index] = sd
+		}
+		switch field {
+		case "NAME":
+			sd.Name = value
+		case "DESC":
+			sd.Description = value
+		}
+	}
+
+	indices := make([]int, 0, len(byIndex))
+	for index := range byIndex {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	subdatasets := make([]Subdataset, 0, len(indices))
+	for _, index := range indices {
+		if byIndex[index].Name != "" {
+			subdatasets = append(subdatasets, *byIndex[index])
+		}
+	}
+	return subdatasets
+}
+
+// OpenSubdataset opens the i'th (0-based) subdataset returned by Subdatasets
+func (dataset Dataset) OpenSubdataset(i int, access Access) (Dataset, error) {
+	subdatasets := dataset.Subdatasets()
+	if i < 0 || i >= len(subdatasets) {
+		return Dataset{nil}, fmt.Errorf("Error: subdataset index %d out of range (dataset has %d subdatasets)", i, len(subdatasets))
+	}
+	return Open(subdatasets[i].Name, access)
+}
+
+// SubdatasetName builds the connection string of a subdataset for the given driver, e.g.
+// NETCDF:"file.nc":variable. component is the variable, array, table or directory number depending on the driver.
+func SubdatasetName(driver, path, component string) (string, error) {
+	quoted := `"` + path + `"`
+	switch strings.ToUpper(driver) {
+	case "NETCDF":
+		return "NETCDF:" + quoted + ":" + component, nil
+	case "HDF5":
+		return "HDF5:" + quoted + "://" + strings.TrimLeft(component, "/"), nil
+	case "HDF4":
+		return "HDF4_SDS:UNKNOWN:" + quoted + ":" + component, nil
+	case "ZARR":
+		return "ZARR:" + quoted + ":/" + strings.TrimLeft(component, "/"), nil
+	case "GPKG":
+		return "GPKG:" + path + ":" + component, nil
+	case "GTIFF":
+		if _, err := strconv.Atoi(component); err != nil {
+			return "", fmt.Errorf("Error: GTiff subdataset component must be a directory number, got '%s'", component)
+		}
+		return "GTIFF_DIR:" + component + ":" + path, nil
+	}
+	return "", fmt.Errorf("Error: subdataset names are not supported for driver '%s'", driver)
+}
+
 // Close closes the dataset
 func (dataset *Dataset) Close() {
 	if dataset.cval != nil {
+		dir, inMemory := takeMemDataset(dataset.cval)
 		C.GDALClose(dataset.cval)
+		if inMemory {
+			VSIRmdirRecursive(dir)
+		}
 		dataset.cval = nil
 	}
 	return
@@ -136,33 +226,10 @@ func (dataset Dataset) IO(
 		dataType = dataset.RasterBand(1).RasterDataType()
 		dataPtr = unsafe.Pointer(&data[0])
 	} else {
-		switch data := buffer.(type) {
-		case []int8:
-			dataType = Byte
-			dataPtr = unsafe.Pointer(&data[0])
-		case []uint8:
-			dataType = Byte
-			dataPtr = unsafe.Pointer(&data[0])
-		case []int16:
-			dataType = Int16
-			dataPtr = unsafe.Pointer(&data[0])
-		case []uint16:
-			dataType = UInt16
-			dataPtr = unsafe.Pointer(&data[0])
-		case []int32:
-			dataType = Int32
-			dataPtr = unsafe.Pointer(&data[0])
-		case []uint32:
-			dataType = UInt32
-			dataPtr = unsafe.Pointer(&data[0])
-		case []float32:
-			dataType = Float32
-			dataPtr = unsafe.Pointer(&data[0])
-		case []float64:
-			dataType = Float64
-			dataPtr = unsafe.Pointer(&data[0])
-		default:
-			return fmt.Errorf("Error: buffer is not a valid data type (must be a valid numeric slice)")
+		var err error
+		dataType, dataPtr, _, err = bufferPointer(buffer)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -42,6 +42,43 @@ func testDataset(t *testing.T) gdal.Dataset {
 	return ds
 }
 
+// skipBeforeGDAL skips a test when GDAL is older than major.minor
+func skipBeforeGDAL(t *testing.T, major, minor int) {
+	if gdal.VERSION_NUM < major*1000000+minor*10000 {
+		t.Skipf("requires GDAL %d.%d or later", major, minor)
+	}
+}
+
+// testVectorDataset creates an empty vector dataset in memory
+func testVectorDataset(t *testing.T) gdal.Dataset {
+	driver, err := gdal.GetDriverByName("Memory")
+	assert.NoError(t, err)
+	return driver.Create("", 0, 0, 0, gdal.Unknown, nil)
+}
+
+// testWKTLayer creates a layer in ds with a "name" field holding the layer name and one feature per WKT geometry,
+// left without geometry when empty
+func testWKTLayer(t *testing.T, ds gdal.Dataset, name string, wkts ...string) gdal.Layer {
+	layer, err := ds.CreateLayer(name, gdal.SpatialReference{}, gdal.GT_Unknown, nil)
+	assert.NoError(t, err)
+	fd := gdal.CreateFieldDefinition("name", gdal.FT_String)
+	assert.NoError(t, layer.CreateField(fd, false))
+	fd.Destroy()
+
+	for _, wkt := range wkts {
+		feature := layer.Definition().Create()
+		feature.SetFieldString(0, name)
+		if wkt != "" {
+			geom, err := gdal.CreateFromWKT(wkt, gdal.SpatialReference{})
+			assert.NoError(t, err)
+			assert.NoError(t, feature.SetGeometryDirectly(geom))
+		}
+		assert.NoError(t, layer.Create(feature))
+		feature.Destroy()
+	}
+	return layer
+}
+
 func TestBasidReadWrite(t *testing.T) {
 
 	ds := testDataset(t)
@@ -72,3 +109,63 @@ func TestDatasetMetadata(t *testing.T) {
 	md := d.Metadata("does not exist")
 	assert.Equal(t, []string(nil), md)
 }
+
+func TestSubdatasets(t *testing.T) {
+	driver, err := gdal.GetDriverByName("MEM")
+	assert.NoError(t, err)
+	src := driver.Create("", 16, 16, 1, gdal.Byte, nil)
+	defer src.Close()
+	src.SetGeoTransform([6]float64{0, 1, 0, 16, 0, -1})
+	src.SetProjection(webMercatorWKT)
+
+	gpkg, err := gdal.GetDriverByName("GPKG")
+	assert.NoError(t, err)
+	name := "/vsimem/subdatasets.gpkg"
+	defer gdal.VSIUnlink(name)
+	out := gpkg.CreateCopy(name, src, 0, []string{"RASTER_TABLE=first"}, nil, nil)
+	out.Close()
+	out = gpkg.CreateCopy(name, src, 0, []string{"RASTER_TABLE=second", "APPEND_SUBDATASET=YES"}, nil, nil)
+	out.Close()
+
+	ds, err := gdal.Open(name, gdal.ReadOnly)
+	assert.NoError(t, err)
+	defer ds.Close()
+
+	sds := ds.Subdatasets()
+	assert.Len(t, sds, 2)
+	assert.Equal(t, "GPKG:/vsimem/subdatasets.gpkg:first", sds[0].Name)
+	assert.NotEmpty(t, sds[1].Description)
+
+	sub, err := ds.OpenSubdataset(1, gdal.ReadOnly)
+	assert.NoError(t, err)
+	defer sub.Close()
+	assert.Equal(t, 16, sub.RasterXSize())
+
+	_, err = ds.OpenSubdataset(2, gdal.ReadOnly)
+	assert.Error(t, err)
+
+	assert.Empty(t, src.Subdatasets())
+}
+
+func TestSubdatasetName(t *testing.T) {
+	name, err := gdal.SubdatasetName("netCDF", "/data/cube.nc", "temperature")
+	assert.NoError(t, err)
+	assert.Equal(t, `NETCDF:"/data/cube.nc":temperature`, name)
+
+	name, err = gdal.SubdatasetName("HDF5", "/data/cube.h5", "/group/temperature")
+	assert.NoError(t, err)
+	assert.Equal(t, `HDF5:"/data/cube.h5"://group/temperature`, name)
+
+	name, err = gdal.SubdatasetName("GPKG", "/data/tiles.gpkg", "first")
+	assert.NoError(t, err)
+	assert.Equal(t, "GPKG:/data/tiles.gpkg:first", name)
+
+	name, err = gdal.SubdatasetName("GTiff", "/data/pages.tif", "2")
+	assert.NoError(t, err)
+	assert.Equal(t, "GTIFF_DIR:2:/data/pages.tif", name)
+
+	_, err = gdal.SubdatasetName("GTiff", "/data/pages.tif", "x")
+	assert.Error(t, err)
+	_, err = gdal.SubdatasetName("MEM", "", "")
+	assert.Error(t, err)
+}
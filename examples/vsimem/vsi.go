@@ -19,11 +19,7 @@ func main() {
 		panic(err)
 	}
 
-	vf, err := gdal.VSIFileFromMemBuffer("/vsimem/test.tif", b, false)
-	if err != nil {
-		panic(err)
-	}
-	ds, err := gdal.Open("/vsimem/test.tif", gdal.ReadOnly)
+	ds, err := gdal.OpenBytes(b, gdal.GDALOFReadOnly|gdal.GDALOFRaster, nil, nil)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -31,8 +27,4 @@ func main() {
 
 	fmt.Println(ds.GeoTransform())
 	fmt.Println(ds.Driver().LongName())
-
-	defer gdal.VSIFCloseL(vf)
-	defer gdal.VSIUnlink("/vsimem/test.tif")
-	
 }
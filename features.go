@@ -0,0 +1,116 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Feature iteration                                               */
+/* -------------------------------------------------------------------- */
+
+// iteratedFeatures holds the features yielded by Layer.Features that are destroyed once the loop body returns
+var iteratedFeatures = struct {
+	sync.Mutex
+	owned map[C.OGRFeatureH]bool
+}{owned: map[C.OGRFeatureH]bool{}}
+
+// Keep stops Layer.Features from destroying the feature after the loop body, which then owns it and must call
+// Destroy. It does nothing for features that do not come from Layer.Features.
+func (feature Feature) Keep() Feature {
+	iteratedFeatures.Lock()
+	delete(iteratedFeatures.owned, feature.cval)
+	iteratedFeatures.Unlock()
+	return feature
+}
+
+// nextFeature fetches the next feature, telling the end of the layer from a read error
+func (layer Layer) nextFeature() (Feature, error) {
+	var feature C.OGRFeatureH
+	cplErr := cplCall(func() {
+		feature = C.OGR_L_GetNextFeature(layer.cval)
+	})
+	if feature == nil && cplErr.failed() {
+		return Feature{nil}, fmt.Errorf("Error: layer '%s' read error: %s", layer.Name(), cplErr.msg)
+	}
+	return Feature{feature}, nil
+}
+
+// Features iterates over the features of the layer that pass its filters, from the first one. Each feature is
+// destroyed after the loop body runs unless Keep is called on it. Iteration stops with ctx.Err() when ctx is
+// done, or with the error of a failed read.
+//
+//	for feature, err := range layer.Features(ctx) {
+//		if err != nil { ... }
+//		...
+//	}
+func (layer Layer) Features(ctx context.Context) iter.Seq2[Feature, error] {
+	return func(yield func(Feature, error) bool) {
+		layer.ResetReading()
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(Feature{nil}, err)
+				return
+			}
+			feature, err := layer.nextFeature()
+			if err != nil {
+				yield(Feature{nil}, err)
+				return
+			}
+			if feature.cval == nil {
+				return
+			}
+
+			iteratedFeatures.Lock()
+			iteratedFeatures.owned[feature.cval] = true
+			iteratedFeatures.Unlock()
+
+			more := yield(feature, nil)
+
+			iteratedFeatures.Lock()
+			owned := iteratedFeatures.owned[feature.cval]
+			delete(iteratedFeatures.owned, feature.cval)
+			iteratedFeatures.Unlock()
+			if owned {
+				feature.Destroy()
+			}
+			if !more {
+				return
+			}
+		}
+	}
+}
+
+// NextBatch fetches up to n of the next features of the layer, continuing from the current read position. Fewer
+// than n features are returned at the end of the layer, and none once it is exhausted. On a read error the
+// features read so far are returned along with it. The caller owns the features and must destroy them. n must be
+// positive.
+func (layer Layer) NextBatch(n int) ([]Feature, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("Error: invalid batch size %d", n)
+	}
+	batch := make([]Feature, 0, n)
+	for len(batch) < n {
+		feature, err := layer.nextFeature()
+		if err != nil {
+			return batch, err
+		}
+		if feature.cval == nil {
+			break
+		}
+		batch = append(batch, feature)
+	}
+	return batch, nil
+}
@@ -0,0 +1,81 @@
+package gdal_test
+
+import (
+	"context"
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayerFeatures(t *testing.T) {
+	ds, err := gdal.OpenBytes([]byte(testGeoJSON), gdal.GDALOFReadOnly|gdal.GDALOFVector, nil, nil)
+	assert.NoError(t, err)
+	defer ds.Close()
+	layer := ds.LayerByIndex(0)
+
+	var names []string
+	var kept gdal.Feature
+	keep := true
+	for feature, err := range layer.Features(context.Background()) {
+		assert.NoError(t, err)
+		names = append(names, feature.FieldAsString(0))
+		if keep {
+			kept = feature.Keep()
+			keep = false
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, names)
+	assert.Equal(t, "a", kept.FieldAsString(0))
+	kept.Destroy()
+
+	// breaking out of the loop and iterating again restarts from the first feature
+	for feature := range layer.Features(context.Background()) {
+		assert.Equal(t, "a", feature.FieldAsString(0))
+		break
+	}
+	count := 0
+	for _, err := range layer.Features(context.Background()) {
+		assert.NoError(t, err)
+		count++
+	}
+	assert.Equal(t, 2, count)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs := 0
+	for _, err := range layer.Features(ctx) {
+		assert.ErrorIs(t, err, context.Canceled)
+		errs++
+	}
+	assert.Equal(t, 1, errs)
+}
+
+func TestLayerNextBatch(t *testing.T) {
+	ds, err := gdal.OpenBytes([]byte(testGeoJSON), gdal.GDALOFReadOnly|gdal.GDALOFVector, nil, nil)
+	assert.NoError(t, err)
+	defer ds.Close()
+	layer := ds.LayerByIndex(0)
+
+	layer.ResetReading()
+	batch, err := layer.NextBatch(1)
+	assert.NoError(t, err)
+	assert.Len(t, batch, 1)
+	assert.Equal(t, "a", batch[0].FieldAsString(0))
+	batch[0].Destroy()
+
+	batch, err = layer.NextBatch(5)
+	assert.NoError(t, err)
+	assert.Len(t, batch, 1)
+	assert.Equal(t, "b", batch[0].FieldAsString(0))
+	batch[0].Destroy()
+
+	batch, err = layer.NextBatch(5)
+	assert.NoError(t, err)
+	assert.Empty(t, batch)
+
+	_, err = layer.NextBatch(0)
+	assert.Error(t, err)
+	_, err = layer.NextBatch(-1)
+	assert.Error(t, err)
+}
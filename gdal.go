@@ -13,8 +13,11 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
+	"runtime"
 	"strings"
+	"time"
 	"unsafe"
 )
 
@@ -84,6 +87,27 @@ func (err OGRErr) Err() error {
 	return ErrIllegal
 }
 
+// cplError is the last error raised by a function run with cplCall
+type cplError struct {
+	class C.CPLErr
+	msg   string
+}
+
+// failed reports whether the error is a failure rather than a warning or a debug message
+func (e cplError) failed() bool {
+	return e.class >= C.CE_Failure
+}
+
+// cplCall resets the CPL error state, runs fn and returns the last error it raised. The error state is per thread,
+// so the goroutine stays locked to its OS thread meanwhile.
+func cplCall(fn func()) cplError {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	C.CPLErrorReset()
+	fn()
+	return cplError{C.CPLGetLastErrorType(), C.GoString(C.CPLGetLastErrorMsg())}
+}
+
 // Pixel data types
 type DataType int
 
@@ -189,6 +213,9 @@ const (
 	GDALOFReadOnly     = OpenFlag(C.GDAL_OF_READONLY)
 	GDALOFUpdate       = OpenFlag(C.GDAL_OF_UPDATE)
 	GDALOFVerboseError = OpenFlag(C.GDAL_OF_VERBOSE_ERROR)
+	GDALOFShared       = OpenFlag(C.GDAL_OF_SHARED)
+	// Open as a multidimensional raster, accessed through Dataset.RootGroup
+	GDALOFMultidimRaster = OpenFlag(C.GDAL_OF_MULTIDIM_RASTER)
 )
 
 // Read/Write flag for RasterIO() method
@@ -228,11 +255,102 @@ const (
 // SetConfigOption
 func SetConfigOption(key, value string) {
 	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
 	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
 
 	C.CPLSetConfigOption(cKey, cValue)
 }
 
+// GetConfigOption fetches a configuration option, looking at thread-local options, then global options, then
+// environment variables
+func GetConfigOption(key, defaultValue string) string {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	cDefault := C.CString(defaultValue)
+	defer C.free(unsafe.Pointer(cDefault))
+
+	return C.GoString(C.CPLGetConfigOption(cKey, cDefault))
+}
+
+// SetThreadLocalConfigOption sets a configuration option for the current OS thread only. Goroutines move between
+// threads, so it should be used with runtime.LockOSThread, or through WithConfig.
+func SetThreadLocalConfigOption(key, value string) {
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
+	setThreadLocalConfigOption(key, cValue)
+}
+
+// setThreadLocalConfigOption sets a thread-local option, or unsets it if value is nil
+func setThreadLocalConfigOption(key string, value *C.char) {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+
+	C.CPLSetThreadLocalConfigOption(cKey, value)
+}
+
+// GetThreadLocalConfigOption fetches a configuration option set for the current OS thread
+func GetThreadLocalConfigOption(key, defaultValue string) string {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	cDefault := C.CString(defaultValue)
+	defer C.free(unsafe.Pointer(cDefault))
+
+	return C.GoString(C.CPLGetThreadLocalConfigOption(cKey, cDefault))
+}
+
+// WithConfig runs fn with options set as thread-local configuration options, leaving other goroutines unaffected,
+// and restores the previous values afterwards. The goroutine is locked to its OS thread while fn runs, so GDAL
+// calls made by goroutines started from fn do not see the options.
+func WithConfig(options map[string]string, fn func() error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	for key, value := range options {
+		cKey := C.CString(key)
+		previous := C.CPLGetThreadLocalConfigOption(cKey, nil)
+		C.free(unsafe.Pointer(cKey))
+
+		if previous == nil {
+			defer setThreadLocalConfigOption(key, nil)
+		} else {
+			saved := C.CString(C.GoString(previous))
+			defer func(key string) {
+				setThreadLocalConfigOption(key, saved)
+				C.free(unsafe.Pointer(saved))
+			}(key)
+		}
+		SetThreadLocalConfigOption(key, value)
+	}
+	return fn()
+}
+
+// configOptionMap converts a KEY=VALUE string list to a map
+func configOptionMap(p **C.char) map[string]string {
+	options := make(map[string]string)
+	for _, kv := range cStringList(p) {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			options[key] = value
+		}
+	}
+	return options
+}
+
+// ConfigOptions lists the global configuration options set with SetConfigOption. Environment variables are not
+// included.
+func ConfigOptions() map[string]string {
+	p := C.CPLGetConfigOptions()
+	defer C.CSLDestroy(p)
+	return configOptionMap(p)
+}
+
+// ThreadLocalConfigOptions lists the configuration options set for the current OS thread
+func ThreadLocalConfigOptions() map[string]string {
+	p := C.CPLGetThreadLocalConfigOptions()
+	defer C.CSLDestroy(p)
+	return configOptionMap(p)
+}
+
 func (colorInterp ColorInterp) Name() string {
 	return C.GoString(C.GDALGetColorInterpretationName(C.GDALColorInterp(colorInterp)))
 }
@@ -309,12 +427,14 @@ type VSIFile struct {
 }
 
 type VSIDir struct {
-	cval *C.VSIDIREntry
+	cval *C.VSIDIR
 }
 
 type VSIStatGo struct {
-	Exists bool
-	Size   int64
+	Exists  bool
+	Size    int64
+	Mode    os.FileMode
+	ModTime time.Time
 }
 
 type BuildVRTOptions struct {
@@ -854,48 +974,71 @@ func (rasterBand RasterBand) IO(
 	bufXSize, bufYSize int,
 	pixelSpace, lineSpace int,
 ) error {
+	dataType, dataPtr, _, err := bufferPointer(buffer)
+	if err != nil {
+		return err
+	}
+
+	return CPLErr(
+		C.GDALRasterIO(
+			rasterBand.cval,
+			C.GDALRWFlag(rwFlag),
+			C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize),
+			dataPtr,
+			C.int(bufXSize), C.int(bufYSize),
+			C.GDALDataType(dataType),
+			C.int(pixelSpace), C.int(lineSpace),
+		),
+	).Err()
+}
+
+// cStringList converts a NULL terminated list of C strings to a Go slice
+func cStringList(p **C.char) []string {
+	var strings []string
+	if p == nil {
+		return strings
+	}
+	q := uintptr(unsafe.Pointer(p))
+	for {
+		p = (**C.char)(unsafe.Pointer(q))
+		if *p == nil {
+			break
+		}
+		strings = append(strings, C.GoString(*p))
+		q += unsafe.Sizeof(q)
+	}
+	return strings
+}
+
+// bufferPointer returns the pixel data type, address of the first element and length of a numeric slice buffer
+func bufferPointer(buffer interface{}) (DataType, unsafe.Pointer, int, error) {
 	var dataType DataType
-	var dataPtr unsafe.Pointer
-	switch data := buffer.(type) {
+	switch buffer.(type) {
 	case []int8:
 		dataType = Byte
-		dataPtr = unsafe.Pointer(&data[0])
 	case []uint8:
 		dataType = Byte
-		dataPtr = unsafe.Pointer(&data[0])
 	case []int16:
 		dataType = Int16
-		dataPtr = unsafe.Pointer(&data[0])
 	case []uint16:
 		dataType = UInt16
-		dataPtr = unsafe.Pointer(&data[0])
 	case []int32:
 		dataType = Int32
-		dataPtr = unsafe.Pointer(&data[0])
 	case []uint32:
 		dataType = UInt32
-		dataPtr = unsafe.Pointer(&data[0])
 	case []float32:
 		dataType = Float32
-		dataPtr = unsafe.Pointer(&data[0])
 	case []float64:
 		dataType = Float64
-		dataPtr = unsafe.Pointer(&data[0])
 	default:
-		return fmt.Errorf("Error: buffer is not a valid data type (must be a valid numeric slice)")
+		return Unknown, nil, 0, fmt.Errorf("Error: buffer is not a valid data type (must be a valid numeric slice)")
 	}
 
-	return CPLErr(
-		C.GDALRasterIO(
-			rasterBand.cval,
-			C.GDALRWFlag(rwFlag),
-			C.int(xOff), C.int(yOff), C.int(xSize), C.int(ySize),
-			dataPtr,
-			C.int(bufXSize), C.int(bufYSize),
-			C.GDALDataType(dataType),
-			C.int(pixelSpace), C.int(lineSpace),
-		),
-	).Err()
+	val := reflect.ValueOf(buffer)
+	if val.Len() == 0 {
+		return Unknown, nil, 0, fmt.Errorf("Error: buffer cannot be 0 length. length=%d", val.Len())
+	}
+	return dataType, val.Index(0).Addr().UnsafePointer(), val.Len(), nil
 }
 
 // Read a block of image data efficiently
@@ -1571,18 +1714,9 @@ func VSIReadDirRecursive(filename string) []string {
 	defer C.free(unsafe.Pointer(name))
 
 	p := C.VSIReadDirRecursive(name)
-	var strings []string
-	q := uintptr(unsafe.Pointer(p))
-	for {
-		p = (**C.char)(unsafe.Pointer(q))
-		if *p == nil {
-			break
-		}
-		strings = append(strings, C.GoString(*p))
-		q += unsafe.Sizeof(q)
-	}
+	defer C.CSLDestroy(p)
 
-	return strings
+	return cStringList(p)
 }
 
 // Open file.
@@ -1605,13 +1739,16 @@ func VSIFCloseL(file VSIFile) {
 	return
 }
 
-// Read bytes from file.
+// Read up to nCount objects of nSize bytes from file. The returned slice is shorter on a short read.
 func VSIFReadL(nSize, nCount int, file VSIFile) []byte {
 	data := make([]byte, nSize*nCount)
+	if len(data) == 0 {
+		return data
+	}
 	p := unsafe.Pointer(&data[0])
-	C.VSIFReadL(p, C.size_t(nSize), C.size_t(nCount), file.cval)
+	n := C.VSIFReadL(p, C.size_t(nSize), C.size_t(nCount), file.cval)
 
-	return data
+	return data[:int(n)*nSize]
 }
 
 // Delete (unlink) a VSI file
@@ -1627,14 +1764,16 @@ func VSIUnlink(filename string) (bool, error) {
 	}
 }
 
+// VSIFileFromMemBuffer creates a /vsimem file holding data. GDAL must not keep pointers to Go memory, so data is
+// always copied into a C buffer that the file owns and releases when unlinked; takeOwnership is ignored.
 func VSIFileFromMemBuffer(filename string, data []byte, takeOwnership bool) (VSIFile, error) {
 
 	cFileName := C.CString(filename)
 	defer C.free(unsafe.Pointer(cFileName))
 
 	l := len(data)
-	p := unsafe.Pointer(&data[0])
-	file := C.VSIFileFromMemBuffer(cFileName, (*C.uchar)(p), C.ulonglong(l), C.FALSE)
+	p := C.CBytes(data)
+	file := C.VSIFileFromMemBuffer(cFileName, (*C.GByte)(p), C.vsi_l_offset(l), C.TRUE)
 
 	if file == nil {
 		return VSIFile{nil}, fmt.Errorf("Error: VSIFileFromMemBuffer '%s' open error", filename)
@@ -17,3 +17,43 @@ func TestDataTypes(t *testing.T) {
 		t.Fail()
 	}
 }
+
+// TestConfigOptions checks that WithConfig options are scoped to the call and its goroutine
+func TestConfigOptions(t *testing.T) {
+	SetConfigOption("GODAL_TEST_GLOBAL", "global")
+	defer SetConfigOption("GODAL_TEST_GLOBAL", "")
+
+	if v := GetConfigOption("GODAL_TEST_GLOBAL", "default"); v != "global" {
+		t.Errorf("global option = %q", v)
+	}
+	if v := ConfigOptions()["GODAL_TEST_GLOBAL"]; v != "global" {
+		t.Errorf("listed global option = %q", v)
+	}
+
+	seenByOthers := make(chan string)
+	err := WithConfig(map[string]string{"GODAL_TEST_GLOBAL": "scoped", "GODAL_TEST_LOCAL": "local"}, func() error {
+		if v := GetConfigOption("GODAL_TEST_GLOBAL", ""); v != "scoped" {
+			t.Errorf("scoped option = %q", v)
+		}
+		if v := ThreadLocalConfigOptions()["GODAL_TEST_LOCAL"]; v != "local" {
+			t.Errorf("listed thread-local option = %q", v)
+		}
+		go func() {
+			seenByOthers <- GetConfigOption("GODAL_TEST_LOCAL", "unset")
+		}()
+		if v := <-seenByOthers; v != "unset" {
+			t.Errorf("thread-local option leaked to another goroutine: %q", v)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if v := GetConfigOption("GODAL_TEST_GLOBAL", ""); v != "global" {
+		t.Errorf("global option after WithConfig = %q", v)
+	}
+	if v := GetConfigOption("GODAL_TEST_LOCAL", "unset"); v != "unset" {
+		t.Errorf("thread-local option after WithConfig = %q", v)
+	}
+}
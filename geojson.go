@@ -0,0 +1,584 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      GeoJSON encoding                                                */
+/* -------------------------------------------------------------------- */
+
+// GeoJSONOptions controls how geometries and features are written as GeoJSON. Coordinates are written as they
+// are: RFC 7946 expects WGS 84 longitude and latitude, so geometries in other spatial references should be
+// transformed first.
+type GeoJSONOptions struct {
+	// Precision is the number of decimals of coordinates, e.g. 6 for about 10 cm in degrees. All significant
+	// digits are written when it is 0.
+	Precision int
+}
+
+func (options GeoJSONOptions) ogrOptions() []string {
+	if options.Precision > 0 {
+		return []string{"COORDINATE_PRECISION=" + strconv.Itoa(options.Precision)}
+	}
+	return nil
+}
+
+// GeoJSON returns the RFC 7946 GeoJSON representation of the geometry, with polygon exterior rings counterclockwise
+// and holes clockwise. A nil geometry is written as null.
+func (geom Geometry) GeoJSON(options GeoJSONOptions) ([]byte, error) {
+	if geom.cval == nil {
+		return []byte("null"), nil
+	}
+
+	ogrOptions := options.ogrOptions()
+	length := len(ogrOptions)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(ogrOptions[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	p := C.OGR_G_ExportToJsonEx(geom.cval, (**C.char)(unsafe.Pointer(&cOptions[0])))
+	if p == nil {
+		return nil, fmt.Errorf("Error: geometry cannot be exported to GeoJSON")
+	}
+	defer C.VSIFree(unsafe.Pointer(p))
+
+	return rightHandRule([]byte(C.GoString(p)))
+}
+
+// MarshalJSON implements json.Marshaler, writing the geometry as GeoJSON with all significant digits
+func (geom Geometry) MarshalJSON() ([]byte, error) {
+	return geom.GeoJSON(GeoJSONOptions{})
+}
+
+// CreateFromGeoJSON creates a geometry from its GeoJSON representation
+func CreateFromGeoJSON(data []byte) (Geometry, error) {
+	cData := C.CString(string(data))
+	defer C.free(unsafe.Pointer(cData))
+
+	var geom C.OGRGeometryH
+	cplErr := cplCall(func() {
+		geom = C.OGR_G_CreateGeometryFromJson(cData)
+	})
+	if geom == nil {
+		return Geometry{nil}, fmt.Errorf("Error: invalid GeoJSON geometry: %s", cplErr.msg)
+	}
+	return Geometry{geom}, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the geometry with one read from GeoJSON. The previous
+// geometry is not destroyed.
+func (geom *Geometry) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		geom.cval = nil
+		return nil
+	}
+	g, err := CreateFromGeoJSON(data)
+	if err != nil {
+		return err
+	}
+	geom.cval = g.cval
+	return nil
+}
+
+// rightHandRule reorients the polygon rings of a GeoJSON geometry written by OGR so that exterior rings are
+// counterclockwise and holes clockwise, as RFC 7946 requires. The geometry is returned unchanged if no ring needs
+// to be reversed.
+func rightHandRule(data []byte) ([]byte, error) {
+	var geom struct {
+		Type        string            `json:"type"`
+		Coordinates json.RawMessage   `json:"coordinates"`
+		Geometries  []json.RawMessage `json:"geometries"`
+	}
+	if err := json.Unmarshal(data, &geom); err != nil {
+		return nil, fmt.Errorf("Error: parsing GeoJSON geometry: %w", err)
+	}
+
+	var coordinates interface{}
+	reversed := false
+	switch geom.Type {
+	case "Polygon":
+		var polygon [][][]float64
+		if err := json.Unmarshal(geom.Coordinates, &polygon); err != nil {
+			return nil, fmt.Errorf("Error: parsing GeoJSON polygon: %w", err)
+		}
+		reversed = orientPolygon(polygon)
+		coordinates = polygon
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(geom.Coordinates, &polygons); err != nil {
+			return nil, fmt.Errorf("Error: parsing GeoJSON multipolygon: %w", err)
+		}
+		for _, polygon := range polygons {
+			reversed = orientPolygon(polygon) || reversed
+		}
+		coordinates = polygons
+	case "GeometryCollection":
+		var buf bytes.Buffer
+		buf.WriteString(`{"type":"GeometryCollection","geometries":[`)
+		for i, g := range geom.Geometries {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			oriented, err := rightHandRule(g)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(oriented)
+		}
+		buf.WriteString("]}")
+		return buf.Bytes(), nil
+	}
+	if !reversed {
+		return data, nil
+	}
+
+	c, err := json.Marshal(coordinates)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(`{"type":"` + geom.Type + `","coordinates":` + string(c) + "}"), nil
+}
+
+// orientPolygon makes the exterior ring of polygon counterclockwise and its holes clockwise, and reports whether
+// any ring was reversed
+func orientPolygon(polygon [][][]float64) bool {
+	reversed := false
+	for i, ring := range polygon {
+		area := 0.0
+		for j := 0; j+1 < len(ring); j++ {
+			if len(ring[j]) < 2 || len(ring[j+1]) < 2 {
+				return reversed
+			}
+			area += ring[j][0]*ring[j+1][1] - ring[j+1][0]*ring[j][1]
+		}
+		if (i == 0 && area < 0) || (i > 0 && area > 0) {
+			for l, r := 0, len(ring)-1; l < r; l, r = l+1, r-1 {
+				ring[l], ring[r] = ring[r], ring[l]
+			}
+			reversed = true
+		}
+	}
+	return reversed
+}
+
+// GeoJSON returns the RFC 7946 GeoJSON Feature representation of the feature. Its identifier is written as "id"
+// unless unset, unset fields are left out of the properties and null fields are written as null.
+func (feature Feature) GeoJSON(options GeoJSONOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"type":"Feature"`)
+	if fid := C.OGR_F_GetFID(feature.cval); fid != C.OGRNullFID {
+		buf.WriteString(`,"id":` + strconv.FormatInt(int64(fid), 10))
+	}
+
+	geom, err := Geometry{C.OGR_F_GetGeometryRef(feature.cval)}.GeoJSON(options)
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteString(`,"geometry":`)
+	buf.Write(geom)
+
+	buf.WriteString(`,"properties":{`)
+	definition := C.OGR_F_GetDefnRef(feature.cval)
+	first := true
+	for i := 0; i < feature.FieldCount(); i++ {
+		if C.OGR_F_IsFieldSet(feature.cval, C.int(i)) == 0 {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		fd := C.OGR_FD_GetFieldDefn(definition, C.int(i))
+		name, _ := json.Marshal(C.GoString(C.OGR_Fld_GetNameRef(fd)))
+		buf.Write(name)
+		buf.WriteByte(':')
+		if C.OGR_F_IsFieldNull(feature.cval, C.int(i)) != 0 {
+			buf.WriteString("null")
+			continue
+		}
+		value, err := feature.fieldGeoJSON(i, fd)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteString("}}")
+	return buf.Bytes(), nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the feature as a GeoJSON Feature with all significant digits
+func (feature Feature) MarshalJSON() ([]byte, error) {
+	return feature.GeoJSON(GeoJSONOptions{})
+}
+
+func geoJSONNumber(f float64) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return []byte("null")
+	}
+	b, _ := json.Marshal(f)
+	return b
+}
+
+// fieldGeoJSON returns the value of the set and not null field at index, described by fd, as JSON
+func (feature Feature) fieldGeoJSON(index int, fd C.OGRFieldDefnH) ([]byte, error) {
+	cIndex := C.int(index)
+	subType := C.OGR_Fld_GetSubType(fd)
+	var count C.int
+
+	switch C.OGR_Fld_GetType(fd) {
+	case C.OFTInteger:
+		value := C.OGR_F_GetFieldAsInteger(feature.cval, cIndex)
+		if subType == C.OFSTBoolean {
+			return json.Marshal(value != 0)
+		}
+		return json.Marshal(int(value))
+	case C.OFTInteger64:
+		return json.Marshal(int64(C.OGR_F_GetFieldAsInteger64(feature.cval, cIndex)))
+	case C.OFTReal:
+		return geoJSONNumber(float64(C.OGR_F_GetFieldAsDouble(feature.cval, cIndex))), nil
+	case C.OFTIntegerList:
+		p := C.OGR_F_GetFieldAsIntegerList(feature.cval, cIndex, &count)
+		if subType == C.OFSTBoolean {
+			list := make([]bool, int(count))
+			for i, x := range unsafe.Slice(p, int(count)) {
+				list[i] = x != 0
+			}
+			return json.Marshal(list)
+		}
+		list := make([]int, int(count))
+		for i, x := range unsafe.Slice(p, int(count)) {
+			list[i] = int(x)
+		}
+		return json.Marshal(list)
+	case C.OFTInteger64List:
+		p := C.OGR_F_GetFieldAsInteger64List(feature.cval, cIndex, &count)
+		list := make([]int64, int(count))
+		for i, x := range unsafe.Slice(p, int(count)) {
+			list[i] = int64(x)
+		}
+		return json.Marshal(list)
+	case C.OFTRealList:
+		p := C.OGR_F_GetFieldAsDoubleList(feature.cval, cIndex, &count)
+		list := [][]byte{}
+		for _, x := range unsafe.Slice(p, int(count)) {
+			list = append(list, geoJSONNumber(float64(x)))
+		}
+		return append(append([]byte{'['}, bytes.Join(list, []byte{','})...), ']'), nil
+	case C.OFTStringList:
+		list := cStringList(C.OGR_F_GetFieldAsStringList(feature.cval, cIndex))
+		if list == nil {
+			list = []string{}
+		}
+		return json.Marshal(list)
+	case C.OFTBinary:
+		p := C.OGR_F_GetFieldAsBinary(feature.cval, cIndex, &count)
+		return json.Marshal(base64.StdEncoding.EncodeToString(C.GoBytes(unsafe.Pointer(p), count)))
+	case C.OFTDate:
+		t, _ := feature.fieldAsTime(index)
+		return json.Marshal(t.Format("2006-01-02"))
+	case C.OFTTime:
+		t, _ := feature.fieldAsTime(index)
+		return json.Marshal(t.Format("15:04:05.999"))
+	case C.OFTDateTime:
+		t, tzFlag := feature.fieldAsTime(index)
+		if tzFlag <= 1 {
+			return json.Marshal(t.Format("2006-01-02T15:04:05.999"))
+		}
+		return json.Marshal(t.Format("2006-01-02T15:04:05.999Z07:00"))
+	}
+
+	value := C.GoString(C.OGR_F_GetFieldAsString(feature.cval, cIndex))
+	if subType == C.OFSTJSON && json.Valid([]byte(value)) {
+		return []byte(value), nil
+	}
+	return json.Marshal(value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, setting the identifier, geometry and fields of the feature from a
+// GeoJSON Feature. The feature must have been created from a definition, e.g. with FeatureDefinition.Create, and
+// properties that match none of its fields are ignored.
+func (feature *Feature) UnmarshalJSON(data []byte) error {
+	if feature.cval == nil {
+		return fmt.Errorf("Error: GeoJSON can only be decoded into a feature created from a definition")
+	}
+	var raw struct {
+		Type       string                     `json:"type"`
+		ID         json.RawMessage            `json:"id"`
+		Geometry   json.RawMessage            `json:"geometry"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("Error: parsing GeoJSON feature: %w", err)
+	}
+	if raw.Type != "Feature" {
+		return fmt.Errorf("Error: GeoJSON type is '%s', not 'Feature'", raw.Type)
+	}
+
+	if fid, err := strconv.ParseInt(string(raw.ID), 10, 64); err == nil {
+		C.OGR_F_SetFID(feature.cval, C.GIntBig(fid))
+	}
+	if len(raw.Geometry) > 0 {
+		var geom Geometry
+		if err := geom.UnmarshalJSON(raw.Geometry); err != nil {
+			return err
+		}
+		if err := OGRErr(C.OGR_F_SetGeometryDirectly(feature.cval, geom.cval)).Err(); err != nil {
+			return err
+		}
+	}
+	for name, value := range raw.Properties {
+		index := feature.FieldIndex(name)
+		if index < 0 {
+			continue
+		}
+		if err := feature.setFieldGeoJSON(index, value); err != nil {
+			return fmt.Errorf("Error: property '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setFieldGeoJSON sets the field at index from a GeoJSON property value
+func (feature Feature) setFieldGeoJSON(index int, data json.RawMessage) error {
+	cIndex := C.int(index)
+	fieldType := C.OGR_Fld_GetType(C.OGR_F_GetFieldDefnRef(feature.cval, cIndex))
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		C.OGR_F_SetFieldNull(feature.cval, cIndex)
+	case json.Number:
+		switch fieldType {
+		case C.OFTInteger, C.OFTInteger64:
+			n, err := v.Int64()
+			if err != nil {
+				return err
+			}
+			C.OGR_F_SetFieldInteger64(feature.cval, cIndex, C.GIntBig(n))
+		case C.OFTReal:
+			f, err := v.Float64()
+			if err != nil {
+				return err
+			}
+			C.OGR_F_SetFieldDouble(feature.cval, cIndex, C.double(f))
+		default:
+			feature.SetFieldString(index, v.String())
+		}
+	case bool:
+		if fieldType == C.OFTInteger || fieldType == C.OFTInteger64 {
+			C.OGR_F_SetFieldInteger(feature.cval, cIndex, BoolToCInt(v))
+		} else {
+			feature.SetFieldString(index, strconv.FormatBool(v))
+		}
+	case string:
+		if fieldType == C.OFTBinary {
+			b, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return err
+			}
+			var p unsafe.Pointer
+			if len(b) > 0 {
+				p = unsafe.Pointer(&b[0])
+			}
+			C.OGR_F_SetFieldBinary(feature.cval, cIndex, C.int(len(b)), p)
+		} else {
+			feature.SetFieldString(index, v)
+		}
+	case []interface{}:
+		n := len(v)
+		switch fieldType {
+		case C.OFTIntegerList, C.OFTInteger64List:
+			list := make([]C.GIntBig, n+1)
+			for i, x := range v {
+				switch x := x.(type) {
+				case json.Number:
+					n, err := x.Int64()
+					if err != nil {
+						return err
+					}
+					list[i] = C.GIntBig(n)
+				case bool:
+					list[i] = C.GIntBig(BoolToCInt(x))
+				default:
+					return fmt.Errorf("Error: %v is not an integer", x)
+				}
+			}
+			C.OGR_F_SetFieldInteger64List(feature.cval, cIndex, C.int(n), &list[0])
+		case C.OFTRealList:
+			list := make([]C.double, n+1)
+			for i, x := range v {
+				number, ok := x.(json.Number)
+				if !ok {
+					return fmt.Errorf("Error: %v is not a number", x)
+				}
+				f, err := number.Float64()
+				if err != nil {
+					return err
+				}
+				list[i] = C.double(f)
+			}
+			C.OGR_F_SetFieldDoubleList(feature.cval, cIndex, C.int(n), &list[0])
+		case C.OFTStringList:
+			list := make([]string, n)
+			for i, x := range v {
+				if s, ok := x.(string); ok {
+					list[i] = s
+				} else {
+					list[i] = fmt.Sprint(x)
+				}
+			}
+			setFieldStringList(feature, index, list)
+		default:
+			feature.SetFieldString(index, string(data))
+		}
+	default:
+		feature.SetFieldString(index, string(data))
+	}
+	return nil
+}
+
+// setFieldStringList is Feature.SetFieldStringList accepting empty lists
+func setFieldStringList(feature Feature, index int, list []string) {
+	length := len(list)
+	cList := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cList[i] = C.CString(list[i])
+		defer C.free(unsafe.Pointer(cList[i]))
+	}
+	cList[length] = (*C.char)(unsafe.Pointer(nil))
+	C.OGR_F_SetFieldStringList(feature.cval, C.int(index), (**C.char)(unsafe.Pointer(&cList[0])))
+}
+
+// GeoJSONEncoder writes layers as GeoJSON FeatureCollections, one feature at a time
+type GeoJSONEncoder struct {
+	w       io.Writer
+	options GeoJSONOptions
+}
+
+// NewGeoJSONEncoder returns an encoder writing to w
+func NewGeoJSONEncoder(w io.Writer, options GeoJSONOptions) *GeoJSONEncoder {
+	return &GeoJSONEncoder{w, options}
+}
+
+// Encode writes the features of the layer that pass its filters, from the first one, as a FeatureCollection
+// followed by a newline. Only one feature is held in memory at a time.
+func (enc *GeoJSONEncoder) Encode(layer Layer) error {
+	if _, err := io.WriteString(enc.w, `{"type":"FeatureCollection","features":[`); err != nil {
+		return err
+	}
+	layer.ResetReading()
+	for i := 0; ; i++ {
+		feature, err := layer.nextFeature()
+		if err != nil {
+			return err
+		}
+		if feature.cval == nil {
+			break
+		}
+		data, err := feature.GeoJSON(enc.options)
+		feature.Destroy()
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			data = append([]byte{',', '\n'}, data...)
+		}
+		if _, err := enc.w.Write(data); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(enc.w, "]}\n")
+	return err
+}
+
+// FeatureCollection wraps a layer to marshal it as, or unmarshal it from, a GeoJSON FeatureCollection
+type FeatureCollection struct {
+	Layer   Layer
+	Options GeoJSONOptions
+
+	dataset Dataset
+}
+
+// MarshalJSON implements json.Marshaler. The whole collection is built in memory: use a GeoJSONEncoder to stream
+// large layers.
+func (fc FeatureCollection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewGeoJSONEncoder(&buf, fc.Options).Encode(fc.Layer); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. If the collection has a layer the features are created in it,
+// otherwise the collection is opened with the GeoJSON driver in memory as a new layer, released by Close.
+func (fc *FeatureCollection) UnmarshalJSON(data []byte) error {
+	if fc.Layer.cval == nil {
+		dataset, err := OpenBytes(data, GDALOFReadOnly|GDALOFVector, []string{"GeoJSON"}, nil)
+		if err != nil {
+			return fmt.Errorf("Error: invalid GeoJSON FeatureCollection")
+		}
+		fc.Close()
+		fc.dataset = dataset
+		fc.Layer = dataset.LayerByIndex(0)
+		return nil
+	}
+
+	var raw struct {
+		Type     string            `json:"type"`
+		Features []json.RawMessage `json:"features"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("Error: parsing GeoJSON FeatureCollection: %w", err)
+	}
+	if raw.Type != "FeatureCollection" {
+		return fmt.Errorf("Error: GeoJSON type is '%s', not 'FeatureCollection'", raw.Type)
+	}
+	definition := fc.Layer.Definition()
+	for _, f := range raw.Features {
+		feature := definition.Create()
+		err := feature.UnmarshalJSON(f)
+		if err == nil {
+			err = fc.Layer.Create(feature)
+		}
+		feature.Destroy()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close releases the dataset opened by UnmarshalJSON, if any
+func (fc *FeatureCollection) Close() {
+	if fc.dataset.cval != nil {
+		fc.dataset.Close()
+		fc.Layer = Layer{nil}
+	}
+}
@@ -0,0 +1,81 @@
+package gdal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeometryJSON(t *testing.T) {
+	// clockwise exterior ring
+	g, err := gdal.CreateFromWKT("POLYGON ((0 0,0 1,1 1,1 0,0 0))", gdal.SpatialReference{})
+	assert.NoError(t, err)
+	defer g.Destroy()
+
+	data, err := json.Marshal(g)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`, string(data))
+
+	p, err := gdal.CreateFromWKT("POINT (1.123456789 2)", gdal.SpatialReference{})
+	assert.NoError(t, err)
+	defer p.Destroy()
+	data, err = p.GeoJSON(gdal.GeoJSONOptions{Precision: 3})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"Point","coordinates":[1.123,2]}`, string(data))
+
+	var decoded struct {
+		Geometry gdal.Geometry `json:"geometry"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"geometry":{"type":"Point","coordinates":[3,4]}}`), &decoded))
+	defer decoded.Geometry.Destroy()
+	wkt, err := decoded.Geometry.ToWKT()
+	assert.NoError(t, err)
+	assert.Equal(t, "POINT (3 4)", wkt)
+
+	_, err = gdal.CreateFromGeoJSON([]byte(`{"type":"Nowhere"}`))
+	assert.Error(t, err)
+	_, err = gdal.CreateFromGeoJSON([]byte(`{"type":`))
+	assert.ErrorContains(t, err, "JSON parsing error")
+}
+
+func TestFeatureJSON(t *testing.T) {
+	var fc gdal.FeatureCollection
+	assert.NoError(t, json.Unmarshal([]byte(testGeoJSON), &fc))
+	defer fc.Close()
+
+	fc.Layer.ResetReading()
+	feature := fc.Layer.NextFeature()
+	data, err := json.Marshal(feature)
+	assert.NoError(t, err)
+	assert.JSONEq(t,
+		`{"type":"Feature","id":0,"geometry":{"type":"Point","coordinates":[1,2]},"properties":{"name":"a","value":1}}`,
+		string(data),
+	)
+
+	copied := fc.Layer.Definition().Create()
+	defer copied.Destroy()
+	assert.NoError(t, json.Unmarshal(data, &copied))
+	assert.Equal(t, "a", copied.FieldAsString(copied.FieldIndex("name")))
+	assert.Equal(t, 1, copied.FieldAsInteger(copied.FieldIndex("value")))
+	feature.Destroy()
+
+	var unbound gdal.Feature
+	assert.Error(t, json.Unmarshal(data, &unbound))
+
+	var buf bytes.Buffer
+	assert.NoError(t, gdal.NewGeoJSONEncoder(&buf, gdal.GeoJSONOptions{}).Encode(fc.Layer))
+	var out struct {
+		Type     string            `json:"type"`
+		Features []json.RawMessage `json:"features"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "FeatureCollection", out.Type)
+	assert.Len(t, out.Features, 2)
+
+	marshaled, err := json.Marshal(fc)
+	assert.NoError(t, err)
+	assert.JSONEq(t, buf.String(), string(marshaled))
+}
@@ -0,0 +1,176 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"sort"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Advanced geometry operations                                    */
+/* -------------------------------------------------------------------- */
+
+// These operations fail with an error, rather than a nil geometry, when GDAL is built without GEOS or is older
+// than the version they require. Voronoi, Relate, RelatePattern and IsValidReason have no OGR counterpart and call
+// GEOS directly, which requires building with the geos tag and linking libgeos_c:
+//
+//	go build -tags geos
+
+// PrecisionFlag changes how SetPrecision snaps geometries to the grid
+type PrecisionFlag int
+
+const (
+	// Snap each vertex without preserving the topology, which may produce invalid geometries
+	PrecisionNoTopology = PrecisionFlag(1)
+	// Keep the elements that collapse to a lower dimension, such as polygons snapped to lines
+	PrecisionKeepCollapsed = PrecisionFlag(2)
+)
+
+// geometryResult runs an OGR operation returning a new geometry, turning a nil one into an error with the last
+// error message the operation raised
+func geometryResult(operation string, fn func() C.OGRGeometryH) (Geometry, error) {
+	var geom C.OGRGeometryH
+	cplErr := cplCall(func() {
+		geom = fn()
+	})
+	if geom != nil {
+		return Geometry{geom}, nil
+	}
+	if cplErr.failed() {
+		return Geometry{nil}, fmt.Errorf("Error: %s failed: %s", operation, cplErr.msg)
+	}
+	return Geometry{nil}, fmt.Errorf("Error: %s failed", operation)
+}
+
+// Compute the union of all components of the geometry. It requires GDAL 3.7 or later.
+func (geom Geometry) UnaryUnion() (Geometry, error) {
+	return geometryResult("unary union", func() C.OGRGeometryH {
+		return C.goOGR_G_UnaryUnion(geom.cval)
+	})
+}
+
+// Return a point guaranteed to lie on the surface of the geometry
+func (geom Geometry) PointOnSurface() (Geometry, error) {
+	return geometryResult("point on surface", func() C.OGRGeometryH {
+		return C.OGR_G_PointOnSurface(geom.cval)
+	})
+}
+
+// Compute the Delaunay triangulation of the vertices of the geometry, as a collection of triangles or of their
+// edges, snapping vertices closer than tolerance together
+func (geom Geometry) DelaunayTriangulation(tolerance float64, onlyEdges bool) (Geometry, error) {
+	return geometryResult("Delaunay triangulation", func() C.OGRGeometryH {
+		return C.OGR_G_DelaunayTriangulation(geom.cval, C.double(tolerance), BoolToCInt(onlyEdges))
+	})
+}
+
+// Compute the Voronoi diagram of the vertices of the geometry, as a collection of polygons or of their edges,
+// snapping vertices closer than tolerance together. The diagram is clipped to the larger of the envelope of clip
+// and an envelope extending slightly beyond the vertices, or to the latter if clip is a nil geometry. It requires
+// the geos build tag.
+func (geom Geometry) Voronoi(clip Geometry, tolerance float64, onlyEdges bool) (Geometry, error) {
+	return geometryResult("Voronoi diagram", func() C.OGRGeometryH {
+		return C.goOGR_G_Voronoi(geom.cval, clip.cval, C.double(tolerance), BoolToCInt(onlyEdges))
+	})
+}
+
+// Compute the concave hull of the geometry, where ratio goes from 0 for the most concave hull to 1 for the convex
+// hull. It requires GDAL 3.6 or later.
+func (geom Geometry) ConcaveHull(ratio float64, allowHoles bool) (Geometry, error) {
+	return geometryResult("concave hull", func() C.OGRGeometryH {
+		return C.goOGR_G_ConcaveHull(geom.cval, C.double(ratio), BoolToCInt(allowHoles))
+	})
+}
+
+// Return a copy of the geometry in normal form, with sorted components and rings starting at their lowest
+// vertex, so that equal geometries have the same representation. It requires GDAL 3.3 or later.
+func (geom Geometry) Normalize() (Geometry, error) {
+	return geometryResult("normalize", func() C.OGRGeometryH {
+		return C.goOGR_G_Normalize(geom.cval)
+	})
+}
+
+// Return a copy of the geometry with its vertices snapped to a grid of cells of size gridSize, or with full
+// precision if gridSize is 0. The result is valid unless flags include PrecisionNoTopology. It requires GDAL 3.9
+// or later.
+func (geom Geometry) SetPrecision(gridSize float64, flags PrecisionFlag) (Geometry, error) {
+	return geometryResult("set precision", func() C.OGRGeometryH {
+		return C.goOGR_G_SetPrecision(geom.cval, C.double(gridSize), C.int(flags))
+	})
+}
+
+// Compute the buffer of the geometry with the style options of OGR_G_BufferEx, e.g. "ENDCAP_STYLE": "FLAT",
+// "JOIN_STYLE": "MITRE", "MITRE_LIMIT", "QUADRANT_SEGMENTS" or "SINGLE_SIDED": "TRUE". It requires GDAL 3.10 or
+// later.
+func (geom Geometry) BufferEx(distance float64, options map[string]string) (Geometry, error) {
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	length := len(keys)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(keys[i] + "=" + options[keys[i]])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	return geometryResult("buffer", func() C.OGRGeometryH {
+		return C.goOGR_G_BufferEx(geom.cval, C.double(distance), (**C.char)(unsafe.Pointer(&cOptions[0])))
+	})
+}
+
+// Compute the DE-9IM intersection matrix of this geometry and the other, e.g. "FF2F11212". It requires the geos
+// build tag.
+func (geom Geometry) Relate(other Geometry) (string, error) {
+	var matrix *C.char
+	cplErr := cplCall(func() {
+		matrix = C.goOGR_G_Relate(geom.cval, other.cval)
+	})
+	if matrix == nil {
+		return "", fmt.Errorf("Error: relate failed: %s", cplErr.msg)
+	}
+	defer C.VSIFree(unsafe.Pointer(matrix))
+	return C.GoString(matrix), nil
+}
+
+// Test if the DE-9IM intersection matrix of this geometry and the other matches pattern, e.g. "T*F**F***" for
+// within. It requires the geos build tag.
+func (geom Geometry) RelatePattern(other Geometry, pattern string) (bool, error) {
+	cPattern := C.CString(pattern)
+	defer C.free(unsafe.Pointer(cPattern))
+
+	var match C.int
+	cplErr := cplCall(func() {
+		match = C.goOGR_G_RelatePattern(geom.cval, other.cval, cPattern)
+	})
+	if match < 0 {
+		return false, fmt.Errorf("Error: relate pattern '%s' failed: %s", pattern, cplErr.msg)
+	}
+	return match != 0, nil
+}
+
+// Return why the geometry is invalid, or "Valid Geometry" if it is valid. It requires the geos build tag.
+func (geom Geometry) IsValidReason() (string, error) {
+	var reason *C.char
+	cplErr := cplCall(func() {
+		reason = C.goOGR_G_IsValidReason(geom.cval)
+	})
+	if reason == nil {
+		return "", fmt.Errorf("Error: validity check failed: %s", cplErr.msg)
+	}
+	defer C.VSIFree(unsafe.Pointer(reason))
+	return C.GoString(reason), nil
+}
@@ -0,0 +1,125 @@
+package gdal_test
+
+import (
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustWKT(t *testing.T, wkt string) gdal.Geometry {
+	geom, err := gdal.CreateFromWKT(wkt, gdal.SpatialReference{})
+	assert.NoError(t, err)
+	return geom
+}
+
+func TestGeometryOperations(t *testing.T) {
+	square := mustWKT(t, "POLYGON ((0 0,0 1,1 1,1 0,0 0))")
+	defer square.Destroy()
+	points := mustWKT(t, "MULTIPOINT (0 0,1 0,0 1,1 1)")
+	defer points.Destroy()
+
+	point, err := square.PointOnSurface()
+	assert.NoError(t, err)
+	assert.True(t, point.Within(square))
+	point.Destroy()
+
+	triangles, err := points.DelaunayTriangulation(0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, triangles.GeometryCount())
+	triangles.Destroy()
+
+	line := mustWKT(t, "LINESTRING (0 0,1 0.001,2 0)")
+	defer line.Destroy()
+	simplified, err := line.SimplifyErr(0.1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, simplified.PointCount())
+	simplified.Destroy()
+
+	edges := mustWKT(t, "MULTILINESTRING ((0 0,0 1),(0 1,1 1),(1 1,1 0),(1 0,0 0))")
+	defer edges.Destroy()
+	polygons, err := edges.PolygonizeErr()
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, polygons.Area(), 1e-9)
+	polygons.Destroy()
+	_, err = points.PolygonizeErr()
+	assert.Error(t, err)
+}
+
+func TestUnaryUnion(t *testing.T) {
+	squares := mustWKT(t, "MULTIPOLYGON (((0 0,0 2,2 2,2 0,0 0)),((1 1,1 3,3 3,3 1,1 1)))")
+	defer squares.Destroy()
+	if gdal.VERSION_NUM < 3070000 {
+		_, err := squares.UnaryUnion()
+		assert.Error(t, err)
+		return
+	}
+	union, err := squares.UnaryUnion()
+	assert.NoError(t, err)
+	defer union.Destroy()
+	assert.Equal(t, gdal.GT_Polygon, union.Type())
+	assert.InDelta(t, 7, union.Area(), 1e-9)
+}
+
+func TestConcaveHull(t *testing.T) {
+	points := mustWKT(t, "MULTIPOINT (0 0,1 0,0 1,1 1,0.5 0.5)")
+	defer points.Destroy()
+	if gdal.VERSION_NUM < 3060000 {
+		_, err := points.ConcaveHull(1, false)
+		assert.Error(t, err)
+		return
+	}
+	hull, err := points.ConcaveHull(1, false)
+	assert.NoError(t, err)
+	defer hull.Destroy()
+	assert.InDelta(t, 1, hull.Area(), 1e-9)
+}
+
+func TestNormalize(t *testing.T) {
+	square := mustWKT(t, "POLYGON ((1 1,1 0,0 0,0 1,1 1))")
+	defer square.Destroy()
+	if gdal.VERSION_NUM < 3030000 {
+		_, err := square.Normalize()
+		assert.Error(t, err)
+		return
+	}
+	normalized, err := square.Normalize()
+	assert.NoError(t, err)
+	defer normalized.Destroy()
+	wkt, err := normalized.ToWKT()
+	assert.NoError(t, err)
+	assert.Equal(t, "POLYGON ((0 0,0 1,1 1,1 0,0 0))", wkt)
+}
+
+func TestSetPrecision(t *testing.T) {
+	line := mustWKT(t, "LINESTRING (0.1 0.2,2.9 3.1)")
+	defer line.Destroy()
+	if gdal.VERSION_NUM < 3090000 {
+		_, err := line.SetPrecision(1, 0)
+		assert.Error(t, err)
+		return
+	}
+	snapped, err := line.SetPrecision(1, 0)
+	assert.NoError(t, err)
+	defer snapped.Destroy()
+	wkt, err := snapped.ToWKT()
+	assert.NoError(t, err)
+	assert.Equal(t, "LINESTRING (0 0,3 3)", wkt)
+}
+
+func TestBufferEx(t *testing.T) {
+	point := mustWKT(t, "POINT (0 0)")
+	defer point.Destroy()
+	if gdal.VERSION_NUM < 3100000 {
+		_, err := point.BufferEx(1, map[string]string{"QUADRANT_SEGMENTS": "1"})
+		assert.Error(t, err)
+		return
+	}
+	diamond, err := point.BufferEx(1, map[string]string{"QUADRANT_SEGMENTS": "1"})
+	assert.NoError(t, err)
+	defer diamond.Destroy()
+	assert.InDelta(t, 2, diamond.Area(), 1e-9)
+
+	_, err = point.BufferEx(1, map[string]string{"ENDCAP_STYLE": "UNKNOWN"})
+	assert.Error(t, err)
+}
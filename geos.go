@@ -0,0 +1,9 @@
+//go:build geos
+
+package gdal
+
+/*
+#cgo CFLAGS: -DGO_GDAL_GEOS
+#cgo LDFLAGS: -lgeos_c
+*/
+import "C"
@@ -0,0 +1,26 @@
+//go:build !geos
+
+package gdal_test
+
+import (
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGEOSOperationsDisabled(t *testing.T) {
+	a := mustWKT(t, "POLYGON ((0 0,0 2,2 2,2 0,0 0))")
+	defer a.Destroy()
+	b := mustWKT(t, "POLYGON ((1 1,1 3,3 3,3 1,1 1))")
+	defer b.Destroy()
+
+	_, err := a.Voronoi(gdal.Geometry{}, 0, false)
+	assert.Error(t, err)
+	_, err = a.Relate(b)
+	assert.Error(t, err)
+	_, err = a.RelatePattern(b, "T*T***T**")
+	assert.Error(t, err)
+	_, err = a.IsValidReason()
+	assert.Error(t, err)
+}
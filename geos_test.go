@@ -0,0 +1,44 @@
+//go:build geos
+
+package gdal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGEOSOperations(t *testing.T) {
+	a := mustWKT(t, "POLYGON ((0 0,0 2,2 2,2 0,0 0))")
+	defer a.Destroy()
+	b := mustWKT(t, "POLYGON ((1 1,1 3,3 3,3 1,1 1))")
+	defer b.Destroy()
+	point := mustWKT(t, "POINT (0.5 0.5)")
+	defer point.Destroy()
+
+	matrix, err := a.Relate(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "212101212", matrix)
+	within, err := point.RelatePattern(a, "T*F**F***")
+	assert.NoError(t, err)
+	assert.True(t, within)
+	within, err = point.RelatePattern(b, "T*F**F***")
+	assert.NoError(t, err)
+	assert.False(t, within)
+
+	reason, err := a.IsValidReason()
+	assert.NoError(t, err)
+	assert.Equal(t, "Valid Geometry", reason)
+	bowtie := mustWKT(t, "POLYGON ((0 0,1 1,1 0,0 1,0 0))")
+	defer bowtie.Destroy()
+	reason, err = bowtie.IsValidReason()
+	assert.NoError(t, err)
+	assert.Contains(t, reason, "Self-intersection")
+
+	points := mustWKT(t, "MULTIPOINT (0 0,2 0)")
+	defer points.Destroy()
+	cells, err := points.Voronoi(a, 0, false)
+	assert.NoError(t, err)
+	defer cells.Destroy()
+	assert.Equal(t, 2, cells.GeometryCount())
+}
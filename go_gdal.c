@@ -6,6 +6,11 @@
 #include "_cgo_export.h"
 
 #include <cpl_conv.h>
+#include <string.h>
+
+#ifdef GO_GDAL_GEOS
+#include <geos_c.h>
+#endif
 
 static int goGDALProgressFuncProxyB_(
 	double complete, 
@@ -21,4 +26,404 @@ GDALProgressFunc goGDALProgressFuncProxyB() {
 	return goGDALProgressFuncProxyB_;
 }
 
+static int goGDALProgressHandleProxyB_(
+	double complete,
+	const char *message,
+	void *progressArg
+) {
+	return goGDALProgressHandleProxyA(complete, (char*)message, (uintptr_t)progressArg);
+}
+
+GDALProgressFunc goGDALProgressHandleProxyB() {
+	return goGDALProgressHandleProxyB_;
+}
+
+static int goGDALTransformerHandleProxyB_(
+	void *transformArg,
+	int dstToSrc,
+	int pointCount,
+	double *x,
+	double *y,
+	double *z,
+	int *success
+) {
+	return goGDALTransformerHandleProxyA((uintptr_t)transformArg, dstToSrc, pointCount, x, y, z, success);
+}
+
+GDALTransformerFunc goGDALTransformerHandleProxyB() {
+	return goGDALTransformerHandleProxyB_;
+}
+
+void goGDALWarpOptionsSetProgressHandle(GDALWarpOptions *options, uintptr_t handle) {
+	options->pfnProgress = goGDALProgressHandleProxyB_;
+	options->pProgressArg = (void*)handle;
+}
+
+void goGDALWarpOptionsSetTransformerHandle(GDALWarpOptions *options, uintptr_t handle) {
+	options->pfnTransformer = goGDALTransformerHandleProxyB_;
+	options->pTransformerArg = (void*)handle;
+}
+
+int goVSIStat(const char *path, int *mode, GIntBig *size, GIntBig *mtime) {
+	VSIStatBufL buf;
+	if (VSIStatL(path, &buf) != 0) {
+		return -1;
+	}
+	*mode = (int)buf.st_mode;
+	*size = (GIntBig)buf.st_size;
+	*mtime = (GIntBig)buf.st_mtime;
+	return 0;
+}
+
+void *goGDALHandleArg(uintptr_t handle) {
+	return (void*)handle;
+}
+
+int goVSICopyFile(const char *src, const char *dst, GDALProgressFunc progress, void *progressArg) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 7, 0)
+	return VSICopyFile(src, dst, NULL, (vsi_l_offset)-1, NULL, progress, progressArg);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "VSICopyFile requires GDAL 3.7 or later");
+	return -1;
+#endif
+}
+
+CPLErr goCPLAddFileInZip(void *handle, const char *name, const char *src, char **options) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 7, 0)
+	return CPLAddFileInZip(handle, name, src, NULL, options, NULL, NULL);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "CPLAddFileInZip requires GDAL 3.7 or later");
+	return CE_Failure;
+#endif
+}
+
+const char *goOGR_Fld_GetComment(OGRFieldDefnH field) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 7, 0)
+	return OGR_Fld_GetComment(field);
+#else
+	return "";
+#endif
+}
+
+int goOGR_Fld_SetComment(OGRFieldDefnH field, const char *comment) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 7, 0)
+	OGR_Fld_SetComment(field, comment);
+	return 0;
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "field comments require GDAL 3.7 or later");
+	return -1;
+#endif
+}
+
+static int goVSIPluginStat_(void *userData, const char *filename, VSIStatBufL *statBuf, int flags) {
+	GIntBig size = 0;
+	if (goVSIPluginStatA((uintptr_t)userData, (char*)filename, &size) != 0) {
+		return -1;
+	}
+	memset(statBuf, 0, sizeof(VSIStatBufL));
+	statBuf->st_size = size;
+	statBuf->st_mode = S_IFREG | 0444;
+	return 0;
+}
+
+static void *goVSIPluginOpen_(void *userData, const char *filename, const char *access) {
+	return (void*)goVSIPluginOpenA((uintptr_t)userData, (char*)filename, (char*)access);
+}
+
+static vsi_l_offset goVSIPluginTell_(void *file) {
+	return (vsi_l_offset)goVSIPluginTellA((uintptr_t)file);
+}
+
+static int goVSIPluginSeek_(void *file, vsi_l_offset offset, int whence) {
+	return goVSIPluginSeekA((uintptr_t)file, (GIntBig)offset, whence);
+}
+
+static size_t goVSIPluginRead_(void *file, void *buffer, size_t size, size_t count) {
+	if (size == 0) {
+		return 0;
+	}
+	return goVSIPluginReadA((uintptr_t)file, buffer, size * count) / size;
+}
+
+static int goVSIPluginReadMultiRange_(void *file, int ranges, void **data, const vsi_l_offset *offsets, const size_t *sizes) {
+	return goVSIPluginReadMultiRangeA((uintptr_t)file, ranges, data, (vsi_l_offset*)offsets, (size_t*)sizes);
+}
+
+static int goVSIPluginEof_(void *file) {
+	return goVSIPluginEofA((uintptr_t)file);
+}
+
+static int goVSIPluginClose_(void *file) {
+	return goVSIPluginCloseA((uintptr_t)file);
+}
+
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 2, 0)
+static char **goVSIPluginSiblingFiles_(void *userData, const char *dirname) {
+	// an empty list tells GDAL there are no side-car files, so it does not probe for them
+	return (char **)CPLCalloc(1, sizeof(char *));
+}
+#endif
+
+int goVSIInstallPluginHandler(const char *prefix, uintptr_t handle) {
+	VSIFilesystemPluginCallbacksStruct *cb = VSIAllocFilesystemPluginCallbacksStruct();
+	cb->pUserData = (void*)handle;
+	cb->stat = goVSIPluginStat_;
+	cb->open = goVSIPluginOpen_;
+	cb->tell = goVSIPluginTell_;
+	cb->seek = goVSIPluginSeek_;
+	cb->read = goVSIPluginRead_;
+	cb->read_multi_range = goVSIPluginReadMultiRange_;
+	cb->eof = goVSIPluginEof_;
+	cb->close = goVSIPluginClose_;
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 2, 0)
+	cb->sibling_files = goVSIPluginSiblingFiles_;
+#endif
+	int ret = VSIInstallPluginHandler(prefix, cb);
+	VSIFreeFilesystemPluginCallbacksStruct(cb);
+	return ret;
+}
+
+static size_t goVSIStdoutWrite_(const void *ptr, size_t size, size_t nmemb, FILE *stream) {
+	if (size == 0) {
+		return 0;
+	}
+	return goVSIStdoutWriteA((uintptr_t)stream, (void*)ptr, size * nmemb) / size;
+}
+
+void goVSIStdoutSetRedirection(uintptr_t handle) {
+	VSIStdoutSetRedirection(goVSIStdoutWrite_, (FILE*)handle);
+}
+
+void goVSIStdoutResetRedirection() {
+	VSIStdoutSetRedirection(fwrite, stdout);
+}
+
+int goArrowStreamGetSchema(struct ArrowArrayStream *stream, struct ArrowSchema *out) {
+	return stream->get_schema(stream, out);
+}
+
+int goArrowStreamGetNext(struct ArrowArrayStream *stream, struct ArrowArray *out) {
+	return stream->get_next(stream, out);
+}
+
+const char *goArrowStreamGetLastError(struct ArrowArrayStream *stream) {
+	return stream->get_last_error(stream);
+}
+
+void goArrowStreamRelease(struct ArrowArrayStream *stream) {
+	if (stream->release != NULL) {
+		stream->release(stream);
+	}
+}
+
+void goArrowSchemaRelease(struct ArrowSchema *schema) {
+	if (schema->release != NULL) {
+		schema->release(schema);
+	}
+}
+
+int goOGR_L_CreateFieldFromArrowSchema(OGRLayerH layer, const struct ArrowSchema *schema, char **options) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 8, 0)
+	return OGR_L_CreateFieldFromArrowSchema(layer, schema, options);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "CreateFieldsFromArrowSchema requires GDAL 3.8 or later");
+	return 0;
+#endif
+}
+
+int goOGR_L_WriteArrowBatch(OGRLayerH layer, const struct ArrowSchema *schema, struct ArrowArray *array, char **options) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 8, 0)
+	return OGR_L_WriteArrowBatch(layer, schema, array, options);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "WriteArrowBatch requires GDAL 3.8 or later");
+	return 0;
+#endif
+}
+
+OGRGeometryH goOGR_G_UnaryUnion(OGRGeometryH geom) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 7, 0)
+	return OGR_G_UnaryUnion(geom);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "UnaryUnion requires GDAL 3.7 or later");
+	return NULL;
+#endif
+}
 
+OGRGeometryH goOGR_G_ConcaveHull(OGRGeometryH geom, double ratio, int allowHoles) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 6, 0)
+	return OGR_G_ConcaveHull(geom, ratio, allowHoles != 0);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "ConcaveHull requires GDAL 3.6 or later");
+	return NULL;
+#endif
+}
+
+OGRGeometryH goOGR_G_Normalize(OGRGeometryH geom) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 3, 0)
+	return OGR_G_Normalize(geom);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "Normalize requires GDAL 3.3 or later");
+	return NULL;
+#endif
+}
+
+OGRGeometryH goOGR_G_SetPrecision(OGRGeometryH geom, double gridSize, int flags) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 9, 0)
+	return OGR_G_SetPrecision(geom, gridSize, flags);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "SetPrecision requires GDAL 3.9 or later");
+	return NULL;
+#endif
+}
+
+OGRGeometryH goOGR_G_BufferEx(OGRGeometryH geom, double distance, char **options) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 10, 0)
+	return OGR_G_BufferEx(geom, distance, options);
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "BufferEx requires GDAL 3.10 or later");
+	return NULL;
+#endif
+}
+
+#ifdef GO_GDAL_GEOS
+static void goGEOSError_(const char *message, void *userData) {
+	CPLError(CE_Failure, CPLE_AppDefined, "GEOS error: %s", message);
+}
+
+static GEOSContextHandle_t goGEOSInit_() {
+	GEOSContextHandle_t ctx = GEOS_init_r();
+	GEOSContext_setErrorMessageHandler_r(ctx, goGEOSError_, NULL);
+	return ctx;
+}
+
+// geometries are passed between OGR and GEOS as WKB
+static GEOSGeometry *goGEOSFromOGR_(GEOSContextHandle_t ctx, OGRGeometryH geom) {
+	int size = OGR_G_WkbSize(geom);
+	unsigned char *wkb = (unsigned char *)CPLMalloc(size);
+	if (OGR_G_ExportToIsoWkb(geom, wkbNDR, wkb) != OGRERR_NONE) {
+		CPLFree(wkb);
+		return NULL;
+	}
+	GEOSWKBReader *reader = GEOSWKBReader_create_r(ctx);
+	GEOSGeometry *g = GEOSWKBReader_read_r(ctx, reader, wkb, size);
+	GEOSWKBReader_destroy_r(ctx, reader);
+	CPLFree(wkb);
+	return g;
+}
+
+static OGRGeometryH goGEOSToOGR_(GEOSContextHandle_t ctx, const GEOSGeometry *g, OGRSpatialReferenceH srs) {
+	GEOSWKBWriter *writer = GEOSWKBWriter_create_r(ctx);
+	GEOSWKBWriter_setOutputDimension_r(ctx, writer, 3);
+	size_t size = 0;
+	unsigned char *wkb = GEOSWKBWriter_write_r(ctx, writer, g, &size);
+	GEOSWKBWriter_destroy_r(ctx, writer);
+	if (wkb == NULL) {
+		return NULL;
+	}
+	OGRGeometryH geom = NULL;
+	OGR_G_CreateFromWkb(wkb, srs, &geom, (int)size);
+	GEOSFree_r(ctx, wkb);
+	return geom;
+}
+#endif
+
+OGRGeometryH goOGR_G_Voronoi(OGRGeometryH geom, OGRGeometryH envelope, double tolerance, int onlyEdges) {
+#ifdef GO_GDAL_GEOS
+	GEOSContextHandle_t ctx = goGEOSInit_();
+	OGRGeometryH result = NULL;
+	GEOSGeometry *g = goGEOSFromOGR_(ctx, geom);
+	GEOSGeometry *env = envelope != NULL ? goGEOSFromOGR_(ctx, envelope) : NULL;
+	if (g != NULL && (envelope == NULL || env != NULL)) {
+		GEOSGeometry *diagram = GEOSVoronoiDiagram_r(ctx, g, env, tolerance, onlyEdges);
+		if (diagram != NULL) {
+			result = goGEOSToOGR_(ctx, diagram, OGR_G_GetSpatialReference(geom));
+			GEOSGeom_destroy_r(ctx, diagram);
+		}
+	}
+	if (env != NULL) {
+		GEOSGeom_destroy_r(ctx, env);
+	}
+	if (g != NULL) {
+		GEOSGeom_destroy_r(ctx, g);
+	}
+	GEOS_finish_r(ctx);
+	return result;
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "Voronoi requires building with the geos tag");
+	return NULL;
+#endif
+}
+
+char *goOGR_G_Relate(OGRGeometryH geom, OGRGeometryH other) {
+#ifdef GO_GDAL_GEOS
+	GEOSContextHandle_t ctx = goGEOSInit_();
+	char *result = NULL;
+	GEOSGeometry *a = goGEOSFromOGR_(ctx, geom);
+	GEOSGeometry *b = goGEOSFromOGR_(ctx, other);
+	if (a != NULL && b != NULL) {
+		char *matrix = GEOSRelate_r(ctx, a, b);
+		if (matrix != NULL) {
+			result = CPLStrdup(matrix);
+			GEOSFree_r(ctx, matrix);
+		}
+	}
+	if (b != NULL) {
+		GEOSGeom_destroy_r(ctx, b);
+	}
+	if (a != NULL) {
+		GEOSGeom_destroy_r(ctx, a);
+	}
+	GEOS_finish_r(ctx);
+	return result;
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "Relate requires building with the geos tag");
+	return NULL;
+#endif
+}
+
+int goOGR_G_RelatePattern(OGRGeometryH geom, OGRGeometryH other, const char *pattern) {
+#ifdef GO_GDAL_GEOS
+	GEOSContextHandle_t ctx = goGEOSInit_();
+	int result = -1;
+	GEOSGeometry *a = goGEOSFromOGR_(ctx, geom);
+	GEOSGeometry *b = goGEOSFromOGR_(ctx, other);
+	if (a != NULL && b != NULL) {
+		char match = GEOSRelatePattern_r(ctx, a, b, pattern);
+		if (match != 2) {
+			result = match;
+		}
+	}
+	if (b != NULL) {
+		GEOSGeom_destroy_r(ctx, b);
+	}
+	if (a != NULL) {
+		GEOSGeom_destroy_r(ctx, a);
+	}
+	GEOS_finish_r(ctx);
+	return result;
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "RelatePattern requires building with the geos tag");
+	return -1;
+#endif
+}
+
+char *goOGR_G_IsValidReason(OGRGeometryH geom) {
+#ifdef GO_GDAL_GEOS
+	GEOSContextHandle_t ctx = goGEOSInit_();
+	char *result = NULL;
+	GEOSGeometry *g = goGEOSFromOGR_(ctx, geom);
+	if (g != NULL) {
+		char *reason = GEOSisValidReason_r(ctx, g);
+		if (reason != NULL) {
+			result = CPLStrdup(reason);
+			GEOSFree_r(ctx, reason);
+		}
+		GEOSGeom_destroy_r(ctx, g);
+	}
+	GEOS_finish_r(ctx);
+	return result;
+#else
+	CPLError(CE_Failure, CPLE_NotSupported, "IsValidReason requires building with the geos tag");
+	return NULL;
+#endif
+}
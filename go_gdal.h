@@ -6,16 +6,75 @@
 #define GO_GDAL_H_
 
 #include <gdal.h>
+#include <gdal_version.h>
 #include <gdal_alg.h>
 #include <gdal_utils.h>
 #include <gdalwarper.h>
 #include <cpl_conv.h>
 #include <cpl_string.h>
 #include <ogr_srs_api.h>
+#include <ogr_api.h>
 
 // transform GDALProgressFunc to go func
 GDALProgressFunc goGDALProgressFuncProxyB();
 
+// transform GDALProgressFunc to go func looked up through a cgo.Handle
+GDALProgressFunc goGDALProgressHandleProxyB();
+
+// transform GDALTransformerFunc to go Transformer looked up through a cgo.Handle
+GDALTransformerFunc goGDALTransformerHandleProxyB();
+
+// store cgo.Handle values as the callback arguments of warp options
+void goGDALWarpOptionsSetProgressHandle(GDALWarpOptions *options, uintptr_t handle);
+void goGDALWarpOptionsSetTransformerHandle(GDALWarpOptions *options, uintptr_t handle);
+
+// pass a cgo.Handle value as a void* callback argument
+void *goGDALHandleArg(uintptr_t handle);
+
+// stat a VSI path, reading fields that are macros in some libc struct stat definitions
+int goVSIStat(const char *path, int *mode, GIntBig *size, GIntBig *mtime);
+
+// copy a VSI file, failing with CPLE_NotSupported before GDAL 3.7
+int goVSICopyFile(const char *src, const char *dst, GDALProgressFunc progress, void *progressArg);
+
+// add a file to a zip archive, failing with CPLE_NotSupported before GDAL 3.7
+CPLErr goCPLAddFileInZip(void *handle, const char *name, const char *src, char **options);
+
+// read and write field comments, empty and failing with CPLE_NotSupported before GDAL 3.7
+const char *goOGR_Fld_GetComment(OGRFieldDefnH field);
+int goOGR_Fld_SetComment(OGRFieldDefnH field, const char *comment);
+
+// install a VSI plugin handler whose callbacks dispatch to the Go handler behind a cgo.Handle
+int goVSIInstallPluginHandler(const char *prefix, uintptr_t handle);
+
+// send /vsistdout/ output to the Go io.Writer behind a cgo.Handle, or back to stdout
+void goVSIStdoutSetRedirection(uintptr_t handle);
+void goVSIStdoutResetRedirection();
+
+// call the callbacks of an Arrow C stream or schema
+int goArrowStreamGetSchema(struct ArrowArrayStream *stream, struct ArrowSchema *out);
+int goArrowStreamGetNext(struct ArrowArrayStream *stream, struct ArrowArray *out);
+const char *goArrowStreamGetLastError(struct ArrowArrayStream *stream);
+void goArrowStreamRelease(struct ArrowArrayStream *stream);
+void goArrowSchemaRelease(struct ArrowSchema *schema);
+
+// write Arrow schemas and batches to a layer, failing with CPLE_NotSupported before GDAL 3.8
+int goOGR_L_CreateFieldFromArrowSchema(OGRLayerH layer, const struct ArrowSchema *schema, char **options);
+int goOGR_L_WriteArrowBatch(OGRLayerH layer, const struct ArrowSchema *schema, struct ArrowArray *array, char **options);
+
+// geometry operations that fail with CPLE_NotSupported on GDAL versions without them
+OGRGeometryH goOGR_G_UnaryUnion(OGRGeometryH geom);
+OGRGeometryH goOGR_G_ConcaveHull(OGRGeometryH geom, double ratio, int allowHoles);
+OGRGeometryH goOGR_G_Normalize(OGRGeometryH geom);
+OGRGeometryH goOGR_G_SetPrecision(OGRGeometryH geom, double gridSize, int flags);
+OGRGeometryH goOGR_G_BufferEx(OGRGeometryH geom, double distance, char **options);
+
+// geometry operations without an OGR counterpart, which call GEOS when built with the geos tag
+OGRGeometryH goOGR_G_Voronoi(OGRGeometryH geom, OGRGeometryH envelope, double tolerance, int onlyEdges);
+char *goOGR_G_Relate(OGRGeometryH geom, OGRGeometryH other);
+int goOGR_G_RelatePattern(OGRGeometryH geom, OGRGeometryH other, const char *pattern);
+char *goOGR_G_IsValidReason(OGRGeometryH geom);
+
 #endif // GO_GDAL_H_
 
 
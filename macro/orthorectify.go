@@ -0,0 +1,48 @@
+package macro
+
+import (
+	"fmt"
+	"path"
+
+	gdal "github.com/seerai/godal"
+)
+
+// Orthorectify warps a raster carrying RPCs (in the "RPC" metadata domain) into dstSRS at the resolution res,
+// using the DEM at dem for terrain heights (or the constant RPC height offset if dem is empty). The result is a
+// Cloud Optimized GeoTIFF written to a private /vsimem file, the first entry of the FileList of the returned
+// dataset, which is deleted when the dataset is closed.
+func Orthorectify(src gdal.Dataset, dem string, dstSRS string, res float64) (gdal.Dataset, error) {
+	if _, err := gdal.ExtractRPCInfoV2(src.Metadata("RPC")); err != nil {
+		return gdal.Dataset{}, fmt.Errorf("reading RPCs: %w", err)
+	}
+	if res <= 0 {
+		return gdal.Dataset{}, fmt.Errorf("invalid resolution %v", res)
+	}
+
+	options := []string{
+		"-of", "COG",
+		"-rpc",
+		"-t_srs", dstSRS,
+		"-tr", fmt.Sprint(res), fmt.Sprint(res),
+		"-r", "bilinear",
+	}
+	rpcOptions := gdal.RPCOptions{DEM: dem}
+	if dem != "" {
+		rpcOptions.DEMInterpolation = gdal.RPC_DEMINTERP_BILINEAR
+	}
+	for _, o := range rpcOptions.Options() {
+		options = append(options, "-to", o)
+	}
+
+	dstName := gdal.NewMemPath("orthorectified.tif")
+	dst, err := gdal.Warp(dstName, gdal.Dataset{}, []gdal.Dataset{src}, options)
+	if err != nil {
+		gdal.VSIRmdirRecursive(path.Dir(dstName))
+		return dst, err
+	}
+	if err := dst.DeleteOnClose(dstName); err != nil {
+		dst.Close()
+		return gdal.Dataset{}, err
+	}
+	return dst, nil
+}
@@ -0,0 +1,111 @@
+package macro
+
+import (
+	"context"
+	"fmt"
+
+	gdal "github.com/seerai/godal"
+)
+
+// SpatialPredicate is the relation between the geometries of a left and a right feature matched by SpatialJoin
+type SpatialPredicate int
+
+const (
+	// The left geometry intersects the right one
+	Intersects SpatialPredicate = iota
+	// The left geometry contains the right one
+	Contains
+	// The left geometry is within the right one, as in a point-in-polygon join with points on the left
+	Within
+)
+
+// SpatialJoinMatch is a pair of features matched by SpatialJoin, identified by their FIDs
+type SpatialJoinMatch struct {
+	Left  int64
+	Right int64
+}
+
+// joinGeometry is a geometry of the right layer, prepared for the predicate tests
+type joinGeometry struct {
+	fid      int64
+	geom     gdal.Geometry
+	prepared gdal.PreparedGeometry
+}
+
+// SpatialJoin returns the pairs of features of left and right whose geometries satisfy predicate, ordered by left
+// feature. The geometries of right are loaded in memory, indexed and prepared, so right should be the smaller
+// layer, typically the polygons of a point-in-polygon join. Features without geometry are never matched. The join
+// stops with ctx.Err() when ctx is cancelled.
+func SpatialJoin(ctx context.Context, left, right gdal.Layer, predicate SpatialPredicate) ([]SpatialJoinMatch, error) {
+	if predicate < Intersects || predicate > Within {
+		return nil, fmt.Errorf("invalid spatial predicate %d", predicate)
+	}
+
+	var geoms []joinGeometry
+	defer func() {
+		for i := range geoms {
+			geoms[i].prepared.Destroy()
+			geoms[i].geom.Destroy()
+		}
+	}()
+	index := gdal.NewSpatialIndex(0)
+	for feature, err := range right.Features(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		geom := feature.StealGeometry()
+		if geom.IsEmpty() {
+			geom.Destroy()
+			continue
+		}
+		g := joinGeometry{fid: feature.FID(), geom: geom}
+		if predicate != Contains {
+			prepared, err := geom.Prepare()
+			if err != nil {
+				geom.Destroy()
+				return nil, err
+			}
+			g.prepared = prepared
+		}
+		index.Insert(geom.Envelope(), int64(len(geoms)))
+		geoms = append(geoms, g)
+	}
+	index.Build()
+
+	var matches []SpatialJoinMatch
+	for feature, err := range left.Features(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		geom := feature.Geometry()
+		candidates := index.QueryGeometry(geom)
+		if len(candidates) == 0 {
+			continue
+		}
+
+		var prepared gdal.PreparedGeometry
+		if predicate == Contains {
+			var err error
+			if prepared, err = geom.Prepare(); err != nil {
+				return nil, err
+			}
+		}
+		for _, i := range candidates {
+			g := geoms[i]
+			var match bool
+			switch predicate {
+			case Intersects:
+				match = g.prepared.Intersects(geom)
+			case Contains:
+				match = prepared.Contains(g.geom)
+			case Within:
+				match = g.prepared.Contains(geom)
+			}
+			if match {
+				matches = append(matches, SpatialJoinMatch{Left: feature.FID(), Right: g.fid})
+			}
+		}
+		prepared.Destroy()
+	}
+	return matches, nil
+}
@@ -0,0 +1,60 @@
+package macro
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+// openGeoJSON opens a GeoJSON feature collection of the geometries, whose FIDs follow their order from 0
+func openGeoJSON(t *testing.T, geometries ...string) gdal.Dataset {
+	features := make([]string, len(geometries))
+	for i, geometry := range geometries {
+		features[i] = `{"type": "Feature", "properties": {}, "geometry": ` + geometry + `}`
+	}
+	collection := `{"type": "FeatureCollection", "features": [` + strings.Join(features, ",") + `]}`
+	ds, err := gdal.OpenBytes([]byte(collection), gdal.GDALOFReadOnly|gdal.GDALOFVector, nil, nil)
+	assert.NoError(t, err)
+	return ds
+}
+
+func TestSpatialJoin(t *testing.T) {
+	ctx := context.Background()
+	pointsDs := openGeoJSON(t,
+		`{"type": "Point", "coordinates": [1, 1]}`,
+		`{"type": "Point", "coordinates": [6, 6]}`,
+		`{"type": "Point", "coordinates": [20, 20]}`,
+		`{"type": "Point", "coordinates": [3, 3]}`,
+	)
+	defer pointsDs.Close()
+	polygonsDs := openGeoJSON(t,
+		`{"type": "Polygon", "coordinates": [[[0, 0], [0, 5], [5, 5], [5, 0], [0, 0]]]}`,
+		`{"type": "Polygon", "coordinates": [[[4, 4], [4, 10], [10, 10], [10, 4], [4, 4]]]}`,
+	)
+	defer polygonsDs.Close()
+	points := pointsDs.LayerByIndex(0)
+	polygons := polygonsDs.LayerByIndex(0)
+
+	matches, err := SpatialJoin(ctx, points, polygons, Within)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []SpatialJoinMatch{{0, 0}, {1, 1}, {3, 0}}, matches)
+
+	matches, err = SpatialJoin(ctx, polygons, points, Contains)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []SpatialJoinMatch{{0, 0}, {0, 3}, {1, 1}}, matches)
+
+	matches, err = SpatialJoin(ctx, polygons, polygons, Intersects)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []SpatialJoinMatch{{0, 0}, {0, 1}, {1, 0}, {1, 1}}, matches)
+
+	_, err = SpatialJoin(ctx, points, polygons, SpatialPredicate(42))
+	assert.Error(t, err)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = SpatialJoin(cancelled, points, polygons, Within)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,61 @@
+package macro
+
+import (
+	"log"
+	"time"
+
+	gdal "github.com/seerai/godal"
+)
+
+// OperationStats summarizes the GDAL activity of an operation run with ReportStats
+type OperationStats struct {
+	Name            string
+	Duration        time.Duration
+	Requests        int64
+	DownloadedBytes int64
+	UploadedBytes   int64
+	CacheUsed       int64
+	CacheMax        int64
+}
+
+// ReportStats runs fn and passes the HTTP requests, bytes transferred and block cache use it caused to report,
+// which typically logs them. Network statistics must have been enabled with gdal.EnableNetworkStats. They are
+// process-wide, so the requests of operations running concurrently with fn are counted too; the statistics are
+// never reset, so concurrent ReportStats calls do not lose each other's requests.
+func ReportStats(name string, report func(OperationStats), fn func() error) error {
+	before := networkTotal()
+	start := time.Now()
+
+	err := fn()
+
+	stats := OperationStats{Name: name, Duration: time.Since(start)}
+	after := networkTotal()
+	stats.Requests = after.Count - before.Count
+	stats.DownloadedBytes = after.DownloadedBytes - before.DownloadedBytes
+	stats.UploadedBytes = after.UploadedBytes - before.UploadedBytes
+	cache := gdal.GetCacheStats()
+	stats.CacheUsed = cache.Used
+	stats.CacheMax = cache.Max
+
+	report(stats)
+	return err
+}
+
+// networkTotal returns the network requests made so far, or none if network statistics are not available
+func networkTotal() gdal.NetworkMethodStats {
+	network, err := gdal.NetworkStats()
+	if err != nil {
+		return gdal.NetworkMethodStats{}
+	}
+	return network.Total()
+}
+
+// LogStats returns a report function for ReportStats that prints one line per operation to logger
+func LogStats(logger *log.Logger) func(OperationStats) {
+	return func(s OperationStats) {
+		logger.Printf(
+			"%s: %v, %d requests, %d bytes downloaded, %d bytes uploaded, block cache %d/%d bytes",
+			s.Name, s.Duration, s.Requests, s.DownloadedBytes, s.UploadedBytes, s.CacheUsed, s.CacheMax,
+		)
+	}
+}
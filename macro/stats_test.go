@@ -0,0 +1,68 @@
+package macro
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gdal.EnableNetworkStats(true)
+}
+
+func TestReportStats(t *testing.T) {
+	content := bytes.Repeat([]byte("y"), 500)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "report.bin", time.Unix(0, 0), bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	read := func(name string) error {
+		f, err := gdal.VSIFOpenL("/vsicurl/"+server.URL+"/"+name, "rb")
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = f.Read(make([]byte, len(content)))
+		return err
+	}
+
+	var reported OperationStats
+	err := ReportStats("read", func(s OperationStats) { reported = s }, func() error {
+		return read("report.bin")
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "read", reported.Name)
+	assert.Greater(t, reported.Requests, int64(0))
+	assert.GreaterOrEqual(t, reported.DownloadedBytes, int64(len(content)))
+	assert.Greater(t, reported.CacheMax, int64(0))
+
+	// the inner operation overlaps the outer one, which counts the requests of both
+	var outer, inner OperationStats
+	err = ReportStats("outer", func(s OperationStats) { outer = s }, func() error {
+		if err := read("outer.bin"); err != nil {
+			return err
+		}
+		return ReportStats("inner", func(s OperationStats) { inner = s }, func() error {
+			return read("inner.bin")
+		})
+	})
+	assert.NoError(t, err)
+	assert.Greater(t, inner.Requests, int64(0))
+	assert.Greater(t, outer.Requests, inner.Requests)
+	assert.GreaterOrEqual(t, outer.DownloadedBytes, int64(2*len(content)))
+
+	var logged bytes.Buffer
+	failure := errors.New("failed")
+	err = ReportStats("noop", LogStats(log.New(&logged, "", 0)), func() error { return failure })
+	assert.Equal(t, failure, err)
+	assert.Contains(t, logged.String(), "noop: ")
+	assert.Contains(t, logged.String(), "0 requests")
+}
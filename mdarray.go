@@ -0,0 +1,651 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+/* ==================================================================== */
+/*      Multidimensional raster API                                     */
+/* ==================================================================== */
+
+type Group struct {
+	cval C.GDALGroupH
+}
+
+type MDArray struct {
+	cval C.GDALMDArrayH
+}
+
+type Dimension struct {
+	cval C.GDALDimensionH
+}
+
+type Attribute struct {
+	cval C.GDALAttributeH
+}
+
+// Class of an extended data type
+type ExtendedDataTypeClass int
+
+const (
+	EDTC_Numeric  = ExtendedDataTypeClass(C.GEDTC_NUMERIC)
+	EDTC_String   = ExtendedDataTypeClass(C.GEDTC_STRING)
+	EDTC_Compound = ExtendedDataTypeClass(C.GEDTC_COMPOUND)
+)
+
+// CreateMultiDimensional creates a new multidimensional dataset with this driver
+func (driver Driver) CreateMultiDimensional(filename string, rootGroupOptions, options []string) (Dataset, error) {
+	name := C.CString(filename)
+	defer C.free(unsafe.Pointer(name))
+
+	cRootOptions := make([]*C.char, len(rootGroupOptions)+1)
+	for i := 0; i < len(rootGroupOptions); i++ {
+		cRootOptions[i] = C.CString(rootGroupOptions[i])
+		defer C.free(unsafe.Pointer(cRootOptions[i]))
+	}
+	cRootOptions[len(rootGroupOptions)] = (*C.char)(unsafe.Pointer(nil))
+
+	cOptions := make([]*C.char, len(options)+1)
+	for i := 0; i < len(options); i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[len(options)] = (*C.char)(unsafe.Pointer(nil))
+
+	h := C.GDALCreateMultiDimensional(
+		driver.cval,
+		name,
+		(**C.char)(unsafe.Pointer(&cRootOptions[0])),
+		(**C.char)(unsafe.Pointer(&cOptions[0])),
+	)
+	if h == nil {
+		return Dataset{nil}, fmt.Errorf("Error: multidimensional dataset '%s' create error", filename)
+	}
+	return Dataset{h}, nil
+}
+
+// RootGroup returns the root group of a dataset opened with GDALOFMultidimRaster. It must be released.
+func (dataset Dataset) RootGroup() (Group, error) {
+	h := C.GDALDatasetGetRootGroup(dataset.cval)
+	if h == nil {
+		return Group{nil}, fmt.Errorf("Error: dataset has no root group (open it with GDALOFMultidimRaster)")
+	}
+	return Group{h}, nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      Groups                                                          */
+/* -------------------------------------------------------------------- */
+
+// Release the group handle
+func (group *Group) Release() {
+	if group.cval != nil {
+		C.GDALGroupRelease(group.cval)
+		group.cval = nil
+	}
+}
+
+// Name of the group
+func (group Group) Name() string {
+	return C.GoString(C.GDALGroupGetName(group.cval))
+}
+
+// FullName of the group, from the root
+func (group Group) FullName() string {
+	return C.GoString(C.GDALGroupGetFullName(group.cval))
+}
+
+// MDArrayNames lists the arrays of this group
+func (group Group) MDArrayNames(options []string) []string {
+	cOptions := make([]*C.char, len(options)+1)
+	for i := 0; i < len(options); i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[len(options)] = (*C.char)(unsafe.Pointer(nil))
+
+	p := C.GDALGroupGetMDArrayNames(group.cval, (**C.char)(unsafe.Pointer(&cOptions[0])))
+	defer C.CSLDestroy(p)
+	return cStringList(p)
+}
+
+// OpenMDArray opens an array of this group by name. It must be released.
+func (group Group) OpenMDArray(name string, options []string) (MDArray, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	cOptions := make([]*C.char, len(options)+1)
+	for i := 0; i < len(options); i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[len(options)] = (*C.char)(unsafe.Pointer(nil))
+
+	h := C.GDALGroupOpenMDArray(group.cval, cName, (**C.char)(unsafe.Pointer(&cOptions[0])))
+	if h == nil {
+		return MDArray{nil}, fmt.Errorf("Error: array '%s' open error", name)
+	}
+	return MDArray{h}, nil
+}
+
+// GroupNames lists the sub-groups of this group
+func (group Group) GroupNames(options []string) []string {
+	cOptions := make([]*C.char, len(options)+1)
+	for i := 0; i < len(options); i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[len(options)] = (*C.char)(unsafe.Pointer(nil))
+
+	p := C.GDALGroupGetGroupNames(group.cval, (**C.char)(unsafe.Pointer(&cOptions[0])))
+	defer C.CSLDestroy(p)
+	return cStringList(p)
+}
+
+// OpenGroup opens a sub-group by name. It must be released.
+func (group Group) OpenGroup(name string, options []string) (Group, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	cOptions := make([]*C.char, len(options)+1)
+	for i := 0; i < len(options); i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[len(options)] = (*C.char)(unsafe.Pointer(nil))
+
+	h := C.GDALGroupOpenGroup(group.cval, cName, (**C.char)(unsafe.Pointer(&cOptions[0])))
+	if h == nil {
+		return Group{nil}, fmt.Errorf("Error: group '%s' open error", name)
+	}
+	return Group{h}, nil
+}
+
+// Dimensions lists the dimensions declared in this group. Each must be released.
+func (group Group) Dimensions() []Dimension {
+	var count C.size_t
+	p := C.GDALGroupGetDimensions(group.cval, &count, nil)
+	return dimensionList(p, count)
+}
+
+// Attributes lists the attributes of this group. Each must be released.
+func (group Group) Attributes() []Attribute {
+	var count C.size_t
+	p := C.GDALGroupGetAttributes(group.cval, &count, nil)
+	return attributeList(p, count)
+}
+
+// CreateGroup creates a sub-group. It must be released.
+func (group Group) CreateGroup(name string) (Group, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	h := C.GDALGroupCreateGroup(group.cval, cName, nil)
+	if h == nil {
+		return Group{nil}, fmt.Errorf("Error: group '%s' create error", name)
+	}
+	return Group{h}, nil
+}
+
+// CreateDimension creates a dimension of the given size. dimType and direction may be empty. It must be released.
+func (group Group) CreateDimension(name, dimType, direction string, size uint64) (Dimension, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	cType := C.CString(dimType)
+	defer C.free(unsafe.Pointer(cType))
+	cDirection := C.CString(direction)
+	defer C.free(unsafe.Pointer(cDirection))
+
+	h := C.GDALGroupCreateDimension(group.cval, cName, cType, cDirection, C.GUInt64(size), nil)
+	if h == nil {
+		return Dimension{nil}, fmt.Errorf("Error: dimension '%s' create error", name)
+	}
+	return Dimension{h}, nil
+}
+
+// CreateMDArray creates a numeric array spanning the given dimensions. It must be released.
+func (group Group) CreateMDArray(name string, dimensions []Dimension, dataType DataType, options []string) (MDArray, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	cOptions := make([]*C.char, len(options)+1)
+	for i := 0; i < len(options); i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[len(options)] = (*C.char)(unsafe.Pointer(nil))
+
+	cDims := make([]C.GDALDimensionH, len(dimensions)+1)
+	for i, d := range dimensions {
+		cDims[i] = d.cval
+	}
+
+	edt := C.GDALExtendedDataTypeCreate(C.GDALDataType(dataType))
+	defer C.GDALExtendedDataTypeRelease(edt)
+
+	h := C.GDALGroupCreateMDArray(
+		group.cval,
+		cName,
+		C.size_t(len(dimensions)),
+		&cDims[0],
+		edt,
+		(**C.char)(unsafe.Pointer(&cOptions[0])),
+	)
+	if h == nil {
+		return MDArray{nil}, fmt.Errorf("Error: array '%s' create error", name)
+	}
+	return MDArray{h}, nil
+}
+
+// CreateAttribute creates a scalar attribute on the group. It must be released.
+func (group Group) CreateAttribute(name string, dataType DataType) (Attribute, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	edt := newExtendedDataType(dataType)
+	defer C.GDALExtendedDataTypeRelease(edt)
+
+	h := C.GDALGroupCreateAttribute(group.cval, cName, 0, nil, edt, nil)
+	if h == nil {
+		return Attribute{nil}, fmt.Errorf("Error: attribute '%s' create error", name)
+	}
+	return Attribute{h}, nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      Dimensions                                                      */
+/* -------------------------------------------------------------------- */
+
+func dimensionList(p *C.GDALDimensionH, count C.size_t) []Dimension {
+	if p == nil {
+		return nil
+	}
+	defer C.VSIFree(unsafe.Pointer(p))
+	handles := unsafe.Slice(p, int(count))
+	dims := make([]Dimension, len(handles))
+	for i, h := range handles {
+		dims[i] = Dimension{h}
+	}
+	return dims
+}
+
+// Release the dimension handle
+func (dim *Dimension) Release() {
+	if dim.cval != nil {
+		C.GDALDimensionRelease(dim.cval)
+		dim.cval = nil
+	}
+}
+
+// Name of the dimension
+func (dim Dimension) Name() string {
+	return C.GoString(C.GDALDimensionGetName(dim.cval))
+}
+
+// FullName of the dimension
+func (dim Dimension) FullName() string {
+	return C.GoString(C.GDALDimensionGetFullName(dim.cval))
+}
+
+// Type of the dimension, e.g. HORIZONTAL_X, HORIZONTAL_Y, VERTICAL or TEMPORAL
+func (dim Dimension) Type() string {
+	return C.GoString(C.GDALDimensionGetType(dim.cval))
+}
+
+// Direction of the dimension, e.g. EAST, NORTH, UP or FUTURE
+func (dim Dimension) Direction() string {
+	return C.GoString(C.GDALDimensionGetDirection(dim.cval))
+}
+
+// Size of the dimension
+func (dim Dimension) Size() uint64 {
+	return uint64(C.GDALDimensionGetSize(dim.cval))
+}
+
+// IndexingVariable returns the array holding the coordinate values of this dimension, if any. It must be released.
+func (dim Dimension) IndexingVariable() (MDArray, bool) {
+	h := C.GDALDimensionGetIndexingVariable(dim.cval)
+	return MDArray{h}, h != nil
+}
+
+// SetIndexingVariable sets the array holding the coordinate values of this dimension
+func (dim Dimension) SetIndexingVariable(array MDArray) error {
+	if C.GDALDimensionSetIndexingVariable(dim.cval, array.cval) == 0 {
+		return fmt.Errorf("Error: cannot set indexing variable of dimension '%s'", dim.Name())
+	}
+	return nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      Arrays                                                          */
+/* -------------------------------------------------------------------- */
+
+// Release the array handle
+func (array *MDArray) Release() {
+	if array.cval != nil {
+		C.GDALMDArrayRelease(array.cval)
+		array.cval = nil
+	}
+}
+
+// Name of the array
+func (array MDArray) Name() string {
+	return C.GoString(C.GDALMDArrayGetName(array.cval))
+}
+
+// FullName of the array
+func (array MDArray) FullName() string {
+	return C.GoString(C.GDALMDArrayGetFullName(array.cval))
+}
+
+// DimensionCount returns the number of dimensions of the array
+func (array MDArray) DimensionCount() int {
+	return int(C.GDALMDArrayGetDimensionCount(array.cval))
+}
+
+// Dimensions of the array, slowest varying first. Each must be released.
+func (array MDArray) Dimensions() []Dimension {
+	var count C.size_t
+	p := C.GDALMDArrayGetDimensions(array.cval, &count)
+	return dimensionList(p, count)
+}
+
+// Shape returns the size of each dimension of the array
+func (array MDArray) Shape() []int {
+	dims := array.Dimensions()
+	shape := make([]int, len(dims))
+	for i := range dims {
+		shape[i] = int(dims[i].Size())
+		dims[i].Release()
+	}
+	return shape
+}
+
+// DataType returns the numeric data type of the array, or Unknown for string and compound arrays
+func (array MDArray) DataType() DataType {
+	edt := C.GDALMDArrayGetDataType(array.cval)
+	defer C.GDALExtendedDataTypeRelease(edt)
+	return DataType(C.GDALExtendedDataTypeGetNumericDataType(edt))
+}
+
+// Unit of the array values
+func (array MDArray) Unit() string {
+	return C.GoString(C.GDALMDArrayGetUnit(array.cval))
+}
+
+// NoDataValue returns the nodata value of the array
+func (array MDArray) NoDataValue() (float64, bool) {
+	var hasNoData C.int
+	val := C.GDALMDArrayGetNoDataValueAsDouble(array.cval, &hasNoData)
+	return float64(val), hasNoData != 0
+}
+
+// SetNoDataValue sets the nodata value of the array
+func (array MDArray) SetNoDataValue(val float64) error {
+	if C.GDALMDArraySetNoDataValueAsDouble(array.cval, C.double(val)) == 0 {
+		return fmt.Errorf("Error: failed to set nodata value on array '%s'", array.Name())
+	}
+	return nil
+}
+
+// SpatialReference of the array. It must be destroyed by the caller.
+func (array MDArray) SpatialReference() (SpatialReference, bool) {
+	sr := C.GDALMDArrayGetSpatialRef(array.cval)
+	return SpatialReference{sr}, sr != nil
+}
+
+// Attributes lists the attributes of this array. Each must be released.
+func (array MDArray) Attributes() []Attribute {
+	var count C.size_t
+	p := C.GDALMDArrayGetAttributes(array.cval, &count, nil)
+	return attributeList(p, count)
+}
+
+// Attribute fetches an attribute of this array by name. It must be released.
+func (array MDArray) Attribute(name string) (Attribute, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	h := C.GDALMDArrayGetAttribute(array.cval, cName)
+	if h == nil {
+		return Attribute{nil}, fmt.Errorf("Error: attribute '%s' not found", name)
+	}
+	return Attribute{h}, nil
+}
+
+// CreateAttribute creates a scalar attribute on the array. It must be released.
+func (array MDArray) CreateAttribute(name string, dataType DataType) (Attribute, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	edt := newExtendedDataType(dataType)
+	defer C.GDALExtendedDataTypeRelease(edt)
+
+	h := C.GDALMDArrayCreateAttribute(array.cval, cName, 0, nil, edt, nil)
+	if h == nil {
+		return Attribute{nil}, fmt.Errorf("Error: attribute '%s' create error", name)
+	}
+	return Attribute{h}, nil
+}
+
+// Read a hyperslab of the array into buffer, a numeric slice as accepted by RasterBand.IO. start and count hold one
+// value per dimension. step may be nil to read contiguous values; negative steps read backwards.
+func (array MDArray) Read(start, count, step []int, buffer interface{}) error {
+	return array.io(Read, start, count, step, buffer)
+}
+
+// Write buffer, a numeric slice as accepted by RasterBand.IO, into a hyperslab of the array
+func (array MDArray) Write(start, count, step []int, buffer interface{}) error {
+	return array.io(Write, start, count, step, buffer)
+}
+
+func (array MDArray) io(rwFlag RWFlag, start, count, step []int, buffer interface{}) error {
+	nDims := array.DimensionCount()
+	if len(start) != nDims || len(count) != nDims {
+		return fmt.Errorf("Error: start and count must have %d values", nDims)
+	}
+	if step != nil && len(step) != nDims {
+		return fmt.Errorf("Error: step must be nil or have %d values", nDims)
+	}
+
+	dataType, dataPtr, length, err := bufferPointer(buffer)
+	if err != nil {
+		return err
+	}
+	total := 1
+	for _, c := range count {
+		total *= c
+	}
+	if total > length {
+		return fmt.Errorf("Error: buffer too small, need %d values, got %d", total, length)
+	}
+
+	var pStart *C.GUInt64
+	var pCount *C.size_t
+	var pStep *C.GInt64
+	if nDims > 0 {
+		cStart := make([]C.GUInt64, nDims)
+		cCount := make([]C.size_t, nDims)
+		for i := 0; i < nDims; i++ {
+			cStart[i] = C.GUInt64(start[i])
+			cCount[i] = C.size_t(count[i])
+		}
+		pStart = &cStart[0]
+		pCount = &cCount[0]
+		if step != nil {
+			cStep := make([]C.GInt64, nDims)
+			for i := 0; i < nDims; i++ {
+				cStep[i] = C.GInt64(step[i])
+			}
+			pStep = &cStep[0]
+		}
+	}
+
+	edt := C.GDALExtendedDataTypeCreate(C.GDALDataType(dataType))
+	defer C.GDALExtendedDataTypeRelease(edt)
+	size := C.size_t(length * dataType.Size() / 8)
+
+	var ok C.int
+	if rwFlag == Read {
+		ok = C.GDALMDArrayRead(array.cval, pStart, pCount, pStep, nil, edt, dataPtr, dataPtr, size)
+	} else {
+		ok = C.GDALMDArrayWrite(array.cval, pStart, pCount, pStep, nil, edt, dataPtr, dataPtr, size)
+	}
+	if ok == 0 {
+		return fmt.Errorf("Error: array '%s' IO failed", array.Name())
+	}
+	return nil
+}
+
+// GetView returns a view of the array selected with NumPy-like syntax, e.g. "[0,::2,1:3]" or "['field']". It must
+// be released.
+func (array MDArray) GetView(expr string) (MDArray, error) {
+	cExpr := C.CString(expr)
+	defer C.free(unsafe.Pointer(cExpr))
+
+	h := C.GDALMDArrayGetView(array.cval, cExpr)
+	if h == nil {
+		return MDArray{nil}, fmt.Errorf("Error: invalid view expression '%s'", expr)
+	}
+	return MDArray{h}, nil
+}
+
+// AsClassicDataset exposes a 1D or 2D slice of the array as a classic raster dataset, with xDim and yDim the indices
+// of the dimensions used as columns and rows. The dataset must be closed.
+func (array MDArray) AsClassicDataset(xDim, yDim int) (Dataset, error) {
+	h := C.GDALMDArrayAsClassicDataset(array.cval, C.size_t(xDim), C.size_t(yDim))
+	if h == nil {
+		return Dataset{nil}, fmt.Errorf("Error: array '%s' cannot be exposed as a classic dataset", array.Name())
+	}
+	return Dataset{h}, nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      Attributes                                                      */
+/* -------------------------------------------------------------------- */
+
+func attributeList(p *C.GDALAttributeH, count C.size_t) []Attribute {
+	if p == nil {
+		return nil
+	}
+	defer C.VSIFree(unsafe.Pointer(p))
+	handles := unsafe.Slice(p, int(count))
+	attrs := make([]Attribute, len(handles))
+	for i, h := range handles {
+		attrs[i] = Attribute{h}
+	}
+	return attrs
+}
+
+func newExtendedDataType(dataType DataType) C.GDALExtendedDataTypeH {
+	if dataType == Unknown {
+		return C.GDALExtendedDataTypeCreateString(0)
+	}
+	return C.GDALExtendedDataTypeCreate(C.GDALDataType(dataType))
+}
+
+// Release the attribute handle
+func (attr *Attribute) Release() {
+	if attr.cval != nil {
+		C.GDALAttributeRelease(attr.cval)
+		attr.cval = nil
+	}
+}
+
+// Name of the attribute
+func (attr Attribute) Name() string {
+	return C.GoString(C.GDALAttributeGetName(attr.cval))
+}
+
+// Class of the attribute data type
+func (attr Attribute) Class() ExtendedDataTypeClass {
+	edt := C.GDALAttributeGetDataType(attr.cval)
+	defer C.GDALExtendedDataTypeRelease(edt)
+	return ExtendedDataTypeClass(C.GDALExtendedDataTypeGetClass(edt))
+}
+
+// ElementCount returns the number of values of the attribute
+func (attr Attribute) ElementCount() int {
+	return int(C.GDALAttributeGetTotalElementsCount(attr.cval))
+}
+
+// ReadAsString reads the first value of the attribute as a string
+func (attr Attribute) ReadAsString() string {
+	return C.GoString(C.GDALAttributeReadAsString(attr.cval))
+}
+
+// ReadAsFloat64 reads the first value of the attribute as a float64
+func (attr Attribute) ReadAsFloat64() float64 {
+	return float64(C.GDALAttributeReadAsDouble(attr.cval))
+}
+
+// ReadAsInt reads the first value of the attribute as an int
+func (attr Attribute) ReadAsInt() int {
+	return int(C.GDALAttributeReadAsInt(attr.cval))
+}
+
+// ReadAsStringList reads all values of the attribute as strings
+func (attr Attribute) ReadAsStringList() []string {
+	p := C.GDALAttributeReadAsStringArray(attr.cval)
+	defer C.CSLDestroy(p)
+	return cStringList(p)
+}
+
+// ReadAsFloat64List reads all values of the attribute as float64
+func (attr Attribute) ReadAsFloat64List() []float64 {
+	var count C.size_t
+	p := C.GDALAttributeReadAsDoubleArray(attr.cval, &count)
+	if p == nil {
+		return nil
+	}
+	defer C.VSIFree(unsafe.Pointer(p))
+	values := make([]float64, int(count))
+	for i, v := range unsafe.Slice(p, int(count)) {
+		values[i] = float64(v)
+	}
+	return values
+}
+
+// Value returns the attribute as a string, float64, []string or []float64 depending on its type and element count
+func (attr Attribute) Value() interface{} {
+	multi := attr.ElementCount() > 1
+	if attr.Class() == EDTC_String {
+		if multi {
+			return attr.ReadAsStringList()
+		}
+		return attr.ReadAsString()
+	}
+	if multi {
+		return attr.ReadAsFloat64List()
+	}
+	return attr.ReadAsFloat64()
+}
+
+// WriteString writes a string value
+func (attr Attribute) WriteString(value string) error {
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
+	if C.GDALAttributeWriteString(attr.cval, cValue) == 0 {
+		return fmt.Errorf("Error: attribute '%s' write error", attr.Name())
+	}
+	return nil
+}
+
+// WriteFloat64 writes a numeric value
+func (attr Attribute) WriteFloat64(value float64) error {
+	if C.GDALAttributeWriteDouble(attr.cval, C.double(value)) == 0 {
+		return fmt.Errorf("Error: attribute '%s' write error", attr.Name())
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package gdal_test
+
+import (
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMDArray(t *testing.T) {
+	driver, err := gdal.GetDriverByName("MEM")
+	assert.NoError(t, err)
+
+	ds, err := driver.CreateMultiDimensional("", nil, nil)
+	assert.NoError(t, err)
+	defer ds.Close()
+
+	root, err := ds.RootGroup()
+	assert.NoError(t, err)
+	defer root.Release()
+
+	time, err := root.CreateDimension("time", "TEMPORAL", "", 2)
+	assert.NoError(t, err)
+	defer time.Release()
+	y, err := root.CreateDimension("y", "HORIZONTAL_Y", "NORTH", 3)
+	assert.NoError(t, err)
+	defer y.Release()
+	x, err := root.CreateDimension("x", "HORIZONTAL_X", "EAST", 4)
+	assert.NoError(t, err)
+	defer x.Release()
+
+	array, err := root.CreateMDArray("temperature", []gdal.Dimension{time, y, x}, gdal.Float32, nil)
+	assert.NoError(t, err)
+	defer array.Release()
+
+	assert.Equal(t, []string{"temperature"}, root.MDArrayNames(nil))
+	assert.Equal(t, 3, array.DimensionCount())
+	assert.Equal(t, []int{2, 3, 4}, array.Shape())
+	assert.Equal(t, gdal.Float32, array.DataType())
+
+	dims := array.Dimensions()
+	assert.Equal(t, "time", dims[0].Name())
+	assert.Equal(t, "HORIZONTAL_X", dims[2].Type())
+	assert.Equal(t, "EAST", dims[2].Direction())
+	for i := range dims {
+		dims[i].Release()
+	}
+
+	values := make([]float32, 2*3*4)
+	for i := range values {
+		values[i] = float32(i)
+	}
+	assert.NoError(t, array.Write([]int{0, 0, 0}, []int{2, 3, 4}, nil, values))
+
+	// second time step, every other column
+	read := make([]float64, 3*2)
+	assert.NoError(t, array.Read([]int{1, 0, 0}, []int{1, 3, 2}, []int{1, 1, 2}, read))
+	assert.Equal(t, []float64{12, 14, 16, 18, 20, 22}, read)
+
+	assert.Error(t, array.Read([]int{0, 0, 0}, []int{2, 3, 4}, nil, read))
+	assert.Error(t, array.Read([]int{0, 0}, []int{2, 3}, nil, read))
+
+	view, err := array.GetView("[1,:,::-1]")
+	assert.NoError(t, err)
+	defer view.Release()
+	assert.Equal(t, []int{3, 4}, view.Shape())
+	row := make([]int32, 4)
+	assert.NoError(t, view.Read([]int{0, 0}, []int{1, 4}, nil, row))
+	assert.Equal(t, []int32{15, 14, 13, 12}, row)
+
+	_, err = array.GetView("[5]")
+	assert.Error(t, err)
+
+	classic, err := view.AsClassicDataset(1, 0)
+	assert.NoError(t, err)
+	defer classic.Close()
+	assert.Equal(t, 4, classic.RasterXSize())
+	assert.Equal(t, 3, classic.RasterYSize())
+
+	unit, err := array.CreateAttribute("units", gdal.Unknown)
+	assert.NoError(t, err)
+	assert.NoError(t, unit.WriteString("degC"))
+	unit.Release()
+	scale, err := array.CreateAttribute("scale", gdal.Float64)
+	assert.NoError(t, err)
+	assert.NoError(t, scale.WriteFloat64(0.5))
+	scale.Release()
+
+	attr, err := array.Attribute("units")
+	assert.NoError(t, err)
+	assert.Equal(t, "degC", attr.Value())
+	attr.Release()
+
+	attrs := array.Attributes()
+	assert.Len(t, attrs, 2)
+	for i := range attrs {
+		if attrs[i].Name() == "scale" {
+			assert.Equal(t, 0.5, attrs[i].Value())
+		}
+		attrs[i].Release()
+	}
+
+	_, err = array.Attribute("missing")
+	assert.Error(t, err)
+}
+
+func TestMultiDimTranslate(t *testing.T) {
+	driver, err := gdal.GetDriverByName("MEM")
+	assert.NoError(t, err)
+
+	ds, err := driver.CreateMultiDimensional("", nil, nil)
+	assert.NoError(t, err)
+	defer ds.Close()
+
+	root, err := ds.RootGroup()
+	assert.NoError(t, err)
+	defer root.Release()
+
+	time, err := root.CreateDimension("time", "TEMPORAL", "", 5)
+	assert.NoError(t, err)
+	defer time.Release()
+	x, err := root.CreateDimension("x", "HORIZONTAL_X", "EAST", 4)
+	assert.NoError(t, err)
+	defer x.Release()
+
+	timeVar, err := root.CreateMDArray("time", []gdal.Dimension{time}, gdal.Float64, nil)
+	assert.NoError(t, err)
+	defer timeVar.Release()
+	assert.NoError(t, timeVar.Write([]int{0}, []int{5}, nil, []float64{10, 20, 30, 40, 50}))
+	assert.NoError(t, time.SetIndexingVariable(timeVar))
+
+	array, err := root.CreateMDArray("data", []gdal.Dimension{time, x}, gdal.Int16, nil)
+	assert.NoError(t, err)
+	defer array.Release()
+	values := make([]int16, 5*4)
+	for i := range values {
+		values[i] = int16(i)
+	}
+	assert.NoError(t, array.Write([]int{0, 0}, []int{5, 4}, nil, values))
+
+	out, err := gdal.MultiDimTranslate("", []gdal.Dataset{ds}, gdal.MultiDimTranslateOptions{
+		Format:    "MEM",
+		Arrays:    []gdal.MDArraySelection{{Name: "data", DstName: "subset"}},
+		Subsets:   []gdal.MDSubset{{Dimension: "time", Min: "20", Max: "40"}},
+		ScaleAxes: []gdal.MDScaleAxis{{Dimension: "x", Factor: 2}},
+	})
+	assert.NoError(t, err)
+	defer out.Close()
+
+	info, err := gdal.MultiDimInfo(out, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "group", info.Type)
+	assert.Contains(t, info.Arrays, "subset")
+	assert.Equal(t, []uint64{3, 2}, info.Arrays["subset"].DimensionSize)
+	assert.Equal(t, "Int16", info.Arrays["subset"].DataType)
+
+	outRoot, err := out.RootGroup()
+	assert.NoError(t, err)
+	defer outRoot.Release()
+	subset, err := outRoot.OpenMDArray("subset", nil)
+	assert.NoError(t, err)
+	defer subset.Release()
+	read := make([]int16, 3*2)
+	assert.NoError(t, subset.Read([]int{0, 0}, []int{3, 2}, nil, read))
+	assert.Equal(t, []int16{4, 6, 8, 10, 12, 14}, read)
+
+	_, err = gdal.MultiDimTranslate("", []gdal.Dataset{ds}, gdal.MultiDimTranslateOptions{
+		Format: "MEM",
+		Arrays: []gdal.MDArraySelection{{Name: "missing"}},
+		Strict: true,
+	})
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"-of", "Zarr",
+		"-array", "name=data,dstname=out,transpose=[1,0],view=[::2]",
+		"-subset", "time(\"2020-01-01\")",
+		"-strict",
+	}, gdal.MultiDimTranslateOptions{
+		Format:  "Zarr",
+		Arrays:  []gdal.MDArraySelection{{Name: "data", DstName: "out", Transpose: []int{1, 0}, View: "[::2]"}},
+		Subsets: []gdal.MDSubset{{Dimension: "time", Min: "\"2020-01-01\""}},
+		Strict:  true,
+	}.Options())
+}
@@ -70,6 +70,16 @@ type Envelope struct {
 	cval C.OGREnvelope
 }
 
+// Create an envelope from its bounds
+func NewEnvelope(minX, minY, maxX, maxY float64) Envelope {
+	var env Envelope
+	env.cval.MinX = C.double(minX)
+	env.cval.MinY = C.double(minY)
+	env.cval.MaxX = C.double(maxX)
+	env.cval.MaxY = C.double(maxY)
+	return env
+}
+
 func (env Envelope) MinX() float64 {
 	return float64(env.cval.MinX)
 }
@@ -223,7 +233,9 @@ func CreateFromWKT(wkt string, srs SpatialReference) (Geometry, error) {
 	).Err()
 }
 
-// Create a geometry object from its GeoJSON representation
+// Create a geometry object from its GeoJSON representation. Invalid input gives a nil geometry.
+//
+// Deprecated: use CreateFromGeoJSON, which reports why the input is invalid.
 func CreateFromJson(_json string) Geometry {
 	cString := C.CString(_json)
 	defer C.free(unsafe.Pointer(cString))
@@ -467,6 +479,13 @@ func (geom Geometry) Simplify(tolerance float64) Geometry {
 	return Geometry{newGeom}
 }
 
+// Simplify the geometry, failing if GDAL is built without GEOS
+func (geom Geometry) SimplifyErr(tolerance float64) (Geometry, error) {
+	return geometryResult("simplify", func() C.OGRGeometryH {
+		return C.OGR_G_Simplify(geom.cval, C.double(tolerance))
+	})
+}
+
 // Simplify the geometry while preserving topology
 func (geom Geometry) SimplifyPreservingTopology(tolerance float64) Geometry {
 	newGeom := C.OGR_G_SimplifyPreserveTopology(geom.cval, C.double(tolerance))
@@ -558,13 +577,6 @@ func (geom Geometry) Union(other Geometry) Geometry {
 
 // Unimplemented: UnionCascaded
 
-// Unimplemented: PointOn Surface (until 2.0)
-// Return a point guaranteed to lie on the surface
-// func (geom Geometry) PointOnSurface() Geometry {
-//	newGeom := C.OGR_G_PointOnSurface(geom.cval)
-//	return Geometry{newGeom}
-// }
-
 // Compute difference between this geometry and the other
 func (geom Geometry) Difference(other Geometry) Geometry {
 	newGeom := C.OGR_G_Difference(geom.cval, other.cval)
@@ -652,6 +664,13 @@ func (geom Geometry) Polygonize() Geometry {
 	return Geometry{newGeom}
 }
 
+// Polygonize a set of sparse edges, failing if they are not a collection of lines
+func (geom Geometry) PolygonizeErr() (Geometry, error) {
+	return geometryResult("polygonize", func() C.OGRGeometryH {
+		return C.OGR_G_Polygonize(geom.cval)
+	})
+}
+
 // Fetch number of points in the geometry
 func (geom Geometry) PointCount() int {
 	count := C.OGR_G_GetPointCount(geom.cval)
@@ -778,8 +797,29 @@ const (
 	FT_Date        = FieldType(C.OFTDate)
 	FT_Time        = FieldType(C.OFTTime)
 	FT_DateTime    = FieldType(C.OFTDateTime)
+	// 64 bit integer types
+	FT_Integer64     = FieldType(C.OFTInteger64)
+	FT_Integer64List = FieldType(C.OFTInteger64List)
 )
 
+// Field subtypes, refining the interpretation of a FieldType
+type FieldSubType int
+
+const (
+	FST_None    = FieldSubType(C.OFSTNone)
+	FST_Boolean = FieldSubType(C.OFSTBoolean)
+	FST_Int16   = FieldSubType(C.OFSTInt16)
+	FST_Float32 = FieldSubType(C.OFSTFloat32)
+	FST_JSON    = FieldSubType(C.OFSTJSON)
+	FST_UUID    = FieldSubType(C.OFSTUUID)
+)
+
+// Fetch human readable name for the field subtype
+func (fst FieldSubType) Name() string {
+	name := C.OGR_GetFieldSubTypeName(C.OGRFieldSubType(fst))
+	return C.GoString(name)
+}
+
 type Justification int
 
 const (
@@ -900,12 +940,170 @@ func (fd FieldDefinition) SetIgnored(ignore bool) {
 	C.OGR_Fld_SetIgnored(fd.cval, BoolToCInt(ignore))
 }
 
+// Fetch the subtype of this field
+func (fd FieldDefinition) SubType() FieldSubType {
+	return FieldSubType(C.OGR_Fld_GetSubType(fd.cval))
+}
+
+// Set the subtype of this field, which must be compatible with its type
+func (fd FieldDefinition) SetSubType(subType FieldSubType) {
+	C.OGR_Fld_SetSubType(fd.cval, C.OGRFieldSubType(subType))
+}
+
+// Fetch whether this field can receive null values
+func (fd FieldDefinition) IsNullable() bool {
+	return C.OGR_Fld_IsNullable(fd.cval) != 0
+}
+
+// Set whether this field can receive null values
+func (fd FieldDefinition) SetNullable(nullable bool) {
+	C.OGR_Fld_SetNullable(fd.cval, BoolToCInt(nullable))
+}
+
+// Fetch whether this field has a unique constraint
+func (fd FieldDefinition) IsUnique() bool {
+	return C.OGR_Fld_IsUnique(fd.cval) != 0
+}
+
+// Set whether this field has a unique constraint
+func (fd FieldDefinition) SetUnique(unique bool) {
+	C.OGR_Fld_SetUnique(fd.cval, BoolToCInt(unique))
+}
+
+// Fetch the default value of this field, as an SQL literal such as 'text', 12, CURRENT_TIMESTAMP or
+// '2020/01/02 12:00:00', or an empty string if it has none
+func (fd FieldDefinition) Default() string {
+	return C.GoString(C.OGR_Fld_GetDefault(fd.cval))
+}
+
+// Set the default value of this field, as an SQL literal. An empty string removes it.
+func (fd FieldDefinition) SetDefault(value string) {
+	if value == "" {
+		C.OGR_Fld_SetDefault(fd.cval, nil)
+		return
+	}
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
+	C.OGR_Fld_SetDefault(fd.cval, cValue)
+}
+
+// Fetch whether the default value of this field is driver specific, and so cannot be used by other drivers
+func (fd FieldDefinition) IsDefaultDriverSpecific() bool {
+	return C.OGR_Fld_IsDefaultDriverSpecific(fd.cval) != 0
+}
+
+// Fetch the comment of this field, which is always empty on GDAL older than 3.7
+func (fd FieldDefinition) Comment() string {
+	return C.GoString(C.goOGR_Fld_GetComment(fd.cval))
+}
+
+// Set the comment of this field, failing on GDAL older than 3.7
+func (fd FieldDefinition) SetComment(comment string) error {
+	cComment := C.CString(comment)
+	defer C.free(unsafe.Pointer(cComment))
+	if C.goOGR_Fld_SetComment(fd.cval, cComment) != 0 {
+		return errors.New("field comments require GDAL 3.7 or later")
+	}
+	return nil
+}
+
+// Fetch the alternative name, or alias, of this field
+func (fd FieldDefinition) AlternativeName() string {
+	return C.GoString(C.OGR_Fld_GetAlternativeNameRef(fd.cval))
+}
+
+// Set the alternative name, or alias, of this field
+func (fd FieldDefinition) SetAlternativeName(name string) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.OGR_Fld_SetAlternativeName(fd.cval, cName)
+}
+
 // Fetch human readable name for the field type
 func (ft FieldType) Name() string {
 	name := C.OGR_GetFieldTypeName(C.OGRFieldType(ft))
 	return C.GoString(name)
 }
 
+/* -------------------------------------------------------------------- */
+/*      Geometry field definition functions                             */
+/* -------------------------------------------------------------------- */
+
+type GeometryFieldDefinition struct {
+	cval C.OGRGeomFieldDefnH
+}
+
+// Create a new geometry field definition
+func CreateGeometryFieldDefinition(name string, geomType GeometryType) GeometryFieldDefinition {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	gfd := C.OGR_GFld_Create(cName, C.OGRwkbGeometryType(geomType))
+	return GeometryFieldDefinition{gfd}
+}
+
+// Destroy the geometry field definition
+func (gfd *GeometryFieldDefinition) Destroy() {
+	if gfd.cval != nil {
+		C.OGR_GFld_Destroy(gfd.cval)
+		gfd.cval = nil
+	}
+}
+
+// Fetch the name of the geometry field
+func (gfd GeometryFieldDefinition) Name() string {
+	name := C.OGR_GFld_GetNameRef(gfd.cval)
+	return C.GoString(name)
+}
+
+// Set the name of the geometry field
+func (gfd GeometryFieldDefinition) SetName(name string) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.OGR_GFld_SetName(gfd.cval, cName)
+}
+
+// Fetch the geometry type of the geometry field
+func (gfd GeometryFieldDefinition) Type() GeometryType {
+	gt := C.OGR_GFld_GetType(gfd.cval)
+	return GeometryType(gt)
+}
+
+// Set the geometry type of the geometry field
+func (gfd GeometryFieldDefinition) SetType(geomType GeometryType) {
+	C.OGR_GFld_SetType(gfd.cval, C.OGRwkbGeometryType(geomType))
+}
+
+// Fetch the spatial reference of the geometry field, owned by the definition
+func (gfd GeometryFieldDefinition) SpatialReference() SpatialReference {
+	sr := C.OGR_GFld_GetSpatialRef(gfd.cval)
+	return SpatialReference{sr}
+}
+
+// Set the spatial reference of the geometry field, which is referenced rather than copied
+func (gfd GeometryFieldDefinition) SetSpatialReference(sr SpatialReference) {
+	C.OGR_GFld_SetSpatialRef(gfd.cval, sr.cval)
+}
+
+// Fetch whether this geometry field can receive null values
+func (gfd GeometryFieldDefinition) IsNullable() bool {
+	return C.OGR_GFld_IsNullable(gfd.cval) != 0
+}
+
+// Set whether this geometry field can receive null values
+func (gfd GeometryFieldDefinition) SetNullable(nullable bool) {
+	C.OGR_GFld_SetNullable(gfd.cval, BoolToCInt(nullable))
+}
+
+// Fetch whether this geometry field should be ignored when fetching features
+func (gfd GeometryFieldDefinition) IsIgnored() bool {
+	return C.OGR_GFld_IsIgnored(gfd.cval) != 0
+}
+
+// Set whether this geometry field should be ignored when fetching features
+func (gfd GeometryFieldDefinition) SetIgnored(ignore bool) {
+	C.OGR_GFld_SetIgnored(gfd.cval, BoolToCInt(ignore))
+}
+
 /* -------------------------------------------------------------------- */
 /*      Feature definition functions                                    */
 /* -------------------------------------------------------------------- */
@@ -1007,6 +1205,36 @@ func (fd FeatureDefinition) SetStyleIgnored(val bool) {
 	C.OGR_FD_SetStyleIgnored(fd.cval, BoolToCInt(val))
 }
 
+// Fetch the number of geometry fields of this definition
+func (fd FeatureDefinition) GeomFieldCount() int {
+	count := C.OGR_FD_GetGeomFieldCount(fd.cval)
+	return int(count)
+}
+
+// Fetch the definition of the indicated geometry field
+func (fd FeatureDefinition) GeomFieldDefinition(index int) GeometryFieldDefinition {
+	gfd := C.OGR_FD_GetGeomFieldDefn(fd.cval, C.int(index))
+	return GeometryFieldDefinition{gfd}
+}
+
+// Fetch the index of the named geometry field, or -1 if there is none
+func (fd FeatureDefinition) GeomFieldIndex(name string) int {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	index := C.OGR_FD_GetGeomFieldIndex(fd.cval, cName)
+	return int(index)
+}
+
+// Add a new geometry field to the definition, which copies it
+func (fd FeatureDefinition) AddGeomFieldDefinition(gfd GeometryFieldDefinition) {
+	C.OGR_FD_AddGeomFieldDefn(fd.cval, gfd.cval)
+}
+
+// Delete a geometry field from the definition
+func (fd FeatureDefinition) DeleteGeomFieldDefinition(index int) error {
+	return OGRErr(C.OGR_FD_DeleteGeomFieldDefn(fd.cval, C.int(index))).Err()
+}
+
 // Increment the reference count by one
 func (fd FeatureDefinition) Reference() int {
 	count := C.OGR_FD_Reference(fd.cval)
@@ -1033,6 +1261,9 @@ type Feature struct {
 	cval C.OGRFeatureH
 }
 
+// Identifier of features that have none, e.g. before being written to a layer
+const NullFID = int64(C.OGRNullFID)
+
 // Create a feature from this feature definition
 func (fd FeatureDefinition) Create() Feature {
 	feature := C.OGR_F_Create(fd.cval)
@@ -1075,6 +1306,42 @@ func (feature Feature) StealGeometry() Geometry {
 	return Geometry{geom}
 }
 
+// Fetch the number of geometry fields of this feature
+func (feature Feature) GeomFieldCount() int {
+	count := C.OGR_F_GetGeomFieldCount(feature.cval)
+	return int(count)
+}
+
+// Fetch the definition of the indicated geometry field
+func (feature Feature) GeomFieldDefinition(index int) GeometryFieldDefinition {
+	gfd := C.OGR_F_GetGeomFieldDefnRef(feature.cval, C.int(index))
+	return GeometryFieldDefinition{gfd}
+}
+
+// Fetch the index of the named geometry field, or -1 if there is none
+func (feature Feature) GeomFieldIndex(name string) int {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	index := C.OGR_F_GetGeomFieldIndex(feature.cval, cName)
+	return int(index)
+}
+
+// Fetch the geometry of the indicated geometry field, owned by the feature
+func (feature Feature) GeomField(index int) Geometry {
+	geom := C.OGR_F_GetGeomFieldRef(feature.cval, C.int(index))
+	return Geometry{geom}
+}
+
+// Set the geometry of the indicated geometry field to a copy of geom
+func (feature Feature) SetGeomField(index int, geom Geometry) error {
+	return OGRErr(C.OGR_F_SetGeomField(feature.cval, C.int(index), geom.cval)).Err()
+}
+
+// Set the geometry of the indicated geometry field, passing ownership of geom to the feature
+func (feature Feature) SetGeomFieldDirectly(index int, geom Geometry) error {
+	return OGRErr(C.OGR_F_SetGeomFieldDirectly(feature.cval, C.int(index), geom.cval)).Err()
+}
+
 // Duplicate feature
 func (feature Feature) Clone() Feature {
 	newFeature := C.OGR_F_Clone(feature.cval)
@@ -1118,6 +1385,21 @@ func (feature Feature) UnnsetField(index int) {
 	C.OGR_F_UnsetField(feature.cval, C.int(index))
 }
 
+// Return if a field is null. A null field is set, as opposed to an unset field.
+func (feature Feature) IsFieldNull(index int) bool {
+	return C.OGR_F_IsFieldNull(feature.cval, C.int(index)) != 0
+}
+
+// Return if a field is set and not null
+func (feature Feature) IsFieldSetAndNotNull(index int) bool {
+	return C.OGR_F_IsFieldSetAndNotNull(feature.cval, C.int(index)) != 0
+}
+
+// Set a field to null
+func (feature Feature) SetFieldNull(index int) {
+	C.OGR_F_SetFieldNull(feature.cval, C.int(index))
+}
+
 // Fetch a reference to the internal field value
 func (feature Feature) RawField(index int) Field {
 	field := C.OGR_F_GetRawFieldRef(feature.cval, C.int(index))
@@ -1130,6 +1412,12 @@ func (feature Feature) FieldAsInteger(index int) int {
 	return int(val)
 }
 
+// Fetch field value as 64 bit integer
+func (feature Feature) FieldAsInteger64(index int) int64 {
+	val := C.OGR_F_GetFieldAsInteger64(feature.cval, C.int(index))
+	return int64(val)
+}
+
 // Fetch field value as float64
 func (feature Feature) FieldAsFloat64(index int) float64 {
 	val := C.OGR_F_GetFieldAsDouble(feature.cval, C.int(index))
@@ -1144,25 +1432,34 @@ func (feature Feature) FieldAsString(index int) string {
 
 // Fetch field as list of integers
 func (feature Feature) FieldAsIntegerList(index int) []int {
-	var count int
-	cArray := C.OGR_F_GetFieldAsIntegerList(feature.cval, C.int(index), (*C.int)(unsafe.Pointer(&count)))
-	var goSlice []int
-	header := (*reflect.SliceHeader)(unsafe.Pointer(&goSlice))
-	header.Cap = count
-	header.Len = count
-	header.Data = uintptr(unsafe.Pointer(cArray))
+	var count C.int
+	cArray := C.OGR_F_GetFieldAsIntegerList(feature.cval, C.int(index), &count)
+	goSlice := make([]int, int(count))
+	for i, v := range unsafe.Slice(cArray, int(count)) {
+		goSlice[i] = int(v)
+	}
+	return goSlice
+}
+
+// Fetch field as list of 64 bit integers
+func (feature Feature) FieldAsInteger64List(index int) []int64 {
+	var count C.int
+	cArray := C.OGR_F_GetFieldAsInteger64List(feature.cval, C.int(index), &count)
+	goSlice := make([]int64, int(count))
+	for i, v := range unsafe.Slice(cArray, int(count)) {
+		goSlice[i] = int64(v)
+	}
 	return goSlice
 }
 
 // Fetch field as list of float64
 func (feature Feature) FieldAsFloat64List(index int) []float64 {
-	var count int
-	cArray := C.OGR_F_GetFieldAsDoubleList(feature.cval, C.int(index), (*C.int)(unsafe.Pointer(&count)))
-	var goSlice []float64
-	header := (*reflect.SliceHeader)(unsafe.Pointer(&goSlice))
-	header.Cap = count
-	header.Len = count
-	header.Data = uintptr(unsafe.Pointer(cArray))
+	var count C.int
+	cArray := C.OGR_F_GetFieldAsDoubleList(feature.cval, C.int(index), &count)
+	goSlice := make([]float64, int(count))
+	for i, v := range unsafe.Slice(cArray, int(count)) {
+		goSlice[i] = float64(v)
+	}
 	return goSlice
 }
 
@@ -1214,11 +1511,17 @@ func (feature Feature) FieldAsDateTime(index int) (time.Time, bool) {
 	return t, success != 0
 }
 
-// Set field to integer value
+// Set field to integer value. Values outside of the 32 bit range are truncated: use SetFieldInteger64 for
+// Integer64 fields.
 func (feature Feature) SetFieldInteger(index, value int) {
 	C.OGR_F_SetFieldInteger(feature.cval, C.int(index), C.int(value))
 }
 
+// Set field to 64 bit integer value
+func (feature Feature) SetFieldInteger64(index int, value int64) {
+	C.OGR_F_SetFieldInteger64(feature.cval, C.int(index), C.GIntBig(value))
+}
+
 // Set field to float64 value
 func (feature Feature) SetFieldFloat64(index int, value float64) {
 	C.OGR_F_SetFieldDouble(feature.cval, C.int(index), C.double(value))
@@ -1233,21 +1536,43 @@ func (feature Feature) SetFieldString(index int, value string) {
 
 // Set field to list of integers
 func (feature Feature) SetFieldIntegerList(index int, value []int) {
+	cValue := make([]C.int, len(value)+1)
+	for i, v := range value {
+		cValue[i] = C.int(v)
+	}
 	C.OGR_F_SetFieldIntegerList(
 		feature.cval,
 		C.int(index),
 		C.int(len(value)),
-		(*C.int)(unsafe.Pointer(&value[0])),
+		&cValue[0],
+	)
+}
+
+// Set field to list of 64 bit integers
+func (feature Feature) SetFieldInteger64List(index int, value []int64) {
+	cValue := make([]C.GIntBig, len(value)+1)
+	for i, v := range value {
+		cValue[i] = C.GIntBig(v)
+	}
+	C.OGR_F_SetFieldInteger64List(
+		feature.cval,
+		C.int(index),
+		C.int(len(value)),
+		&cValue[0],
 	)
 }
 
 // Set field to list of float64
 func (feature Feature) SetFieldFloat64List(index int, value []float64) {
+	cValue := make([]C.double, len(value)+1)
+	for i, v := range value {
+		cValue[i] = C.double(v)
+	}
 	C.OGR_F_SetFieldDoubleList(
 		feature.cval,
 		C.int(index),
 		C.int(len(value)),
-		(*C.double)(unsafe.Pointer(&value[0])),
+		&cValue[0],
 	)
 }
 
@@ -1298,14 +1623,14 @@ func (feature Feature) SetFieldDateTime(index int, dt time.Time) {
 	)
 }
 
-// Fetch feature indentifier
-func (feature Feature) FID() int {
+// Fetch feature indentifier, or NullFID if it has none
+func (feature Feature) FID() int64 {
 	fid := C.OGR_F_GetFID(feature.cval)
-	return int(fid)
+	return int64(fid)
 }
 
 // Set feature identifier
-func (feature Feature) SetFID(fid int) error {
+func (feature Feature) SetFID(fid int64) error {
 	return OGRErr(C.OGR_F_SetFID(feature.cval, C.GIntBig(fid))).Err()
 }
 
@@ -1379,6 +1704,20 @@ func (layer Layer) SetSpatialFilterRect(minX, minY, maxX, maxY float64) {
 	)
 }
 
+// Set a new spatial filter on the indicated geometry field of this layer
+func (layer Layer) SetSpatialFilterEx(geomField int, filter Geometry) {
+	C.OGR_L_SetSpatialFilterEx(layer.cval, C.int(geomField), filter.cval)
+}
+
+// Set a new rectangular spatial filter on the indicated geometry field of this layer
+func (layer Layer) SetSpatialFilterRectEx(geomField int, minX, minY, maxX, maxY float64) {
+	C.OGR_L_SetSpatialFilterRectEx(
+		layer.cval,
+		C.int(geomField),
+		C.double(minX), C.double(minY), C.double(maxX), C.double(maxY),
+	)
+}
+
 // Set a new attribute query filter
 func (layer Layer) SetAttributeFilter(filter string) error {
 	cFilter := C.CString(filter)
@@ -1398,13 +1737,13 @@ func (layer Layer) NextFeature() Feature {
 }
 
 // Move read cursor to the provided index
-func (layer Layer) SetNextByIndex(index int) error {
+func (layer Layer) SetNextByIndex(index int64) error {
 	return OGRErr(C.OGR_L_SetNextByIndex(layer.cval, C.GIntBig(index))).Err()
 }
 
-// Fetch a feature by its index
-func (layer Layer) Feature(index int) Feature {
-	feature := C.OGR_L_GetFeature(layer.cval, C.GIntBig(index))
+// Fetch a feature by its identifier
+func (layer Layer) Feature(fid int64) Feature {
+	feature := C.OGR_L_GetFeature(layer.cval, C.GIntBig(fid))
 	return Feature{feature}
 }
 
@@ -1419,8 +1758,8 @@ func (layer Layer) Create(feature Feature) error {
 }
 
 // Delete indicated feature from layer
-func (layer Layer) Delete(index int) error {
-	return OGRErr(C.OGR_L_DeleteFeature(layer.cval, C.GIntBig(index))).Err()
+func (layer Layer) Delete(fid int64) error {
+	return OGRErr(C.OGR_L_DeleteFeature(layer.cval, C.GIntBig(fid))).Err()
 }
 
 // Fetch the schema information for this layer
@@ -1460,6 +1799,11 @@ func (layer Layer) CreateField(fd FieldDefinition, approxOK bool) error {
 	return OGRErr(C.OGR_L_CreateField(layer.cval, fd.cval, BoolToCInt(approxOK))).Err()
 }
 
+// Create a new geometry field on a layer
+func (layer Layer) CreateGeomField(gfd GeometryFieldDefinition, approxOK bool) error {
+	return OGRErr(C.OGR_L_CreateGeomField(layer.cval, gfd.cval, BoolToCInt(approxOK))).Err()
+}
+
 // Delete a field from the layer
 func (layer Layer) DeleteField(index int) error {
 	return OGRErr(C.OGR_L_DeleteField(layer.cval, C.int(index))).Err()
@@ -1525,56 +1869,42 @@ func (layer Layer) SetIgnoredFields(names []string) error {
 	return OGRErr(C.OGR_L_SetIgnoredFields(layer.cval, (**C.char)(unsafe.Pointer(&cNames[0])))).Err()
 }
 
-// Return the intersection of two layers
-// Unimplemented: Intersection
-// Will be new in 2.0
-
-// Return the union of two layers
-// Unimplemented: Union
-// Will be new in 2.0
-
-// Return the symmetric difference of two layers
-// Unimplemented: SymDifference
-// Will be new in 2.0
-
-// Identify features in this layer with ones from the provided layer
-// Unimplemented: Identity
-// Will be new in 2.0
-
-// Update this layer with features from the provided layer
-// Unimplemented: Update
-// Will be new in 2.0
-
-// Clip off areas that are not covered by the provided layer
-// Unimplemented: Clip
-// Will be new in 2.0
-
-// Remove areas that are covered by the provided layer
-// Unimplemented: Erase
-// Will be new in 2.0
-
 /* -------------------------------------------------------------------- */
 /*      Data source functions                                           */
 /* -------------------------------------------------------------------- */
 
+// DataSource is a vector dataset.
+//
+// Deprecated: open vector datasets with OpenEx and GDALOFVector and use the layer methods of Dataset.
+// DataSource is kept as a thin wrapper around them for compatibility.
 type DataSource struct {
 	cval C.OGRDataSourceH
 }
 
+func openDataSource(name string, update int, flags OpenFlag) DataSource {
+	flags |= GDALOFVector
+	if update != 0 {
+		flags |= GDALOFUpdate
+	}
+	dataset, err := OpenEx(name, flags, nil, nil, nil)
+	if err != nil {
+		return DataSource{nil}
+	}
+	return DataSource{(C.OGRDataSourceH)(dataset.cval)}
+}
+
 // Open a file / data source with one of the registered drivers
+//
+// Deprecated: use OpenEx with GDALOFVector.
 func OpenDataSource(name string, update int) DataSource {
-	cName := C.CString(name)
-	defer C.free(unsafe.Pointer(cName))
-	ds := C.OGROpen(cName, C.int(update), nil)
-	return DataSource{ds}
+	return openDataSource(name, update, 0)
 }
 
 // Open a shared file / data source with one of the registered drivers
+//
+// Deprecated: use OpenEx with GDALOFVector|GDALOFShared.
 func OpenSharedDataSource(name string, update int) DataSource {
-	cName := C.CString(name)
-	defer C.free(unsafe.Pointer(cName))
-	ds := C.OGROpenShared(cName, C.int(update), nil)
-	return DataSource{ds}
+	return openDataSource(name, update, GDALOFShared)
 }
 
 // Drop a reference to this datasource and destroy if reference is zero
@@ -1609,46 +1939,43 @@ func (ds DataSource) ToDataset() Dataset {
 // Closes datasource and releases resources
 func (ds *DataSource) Destroy() {
 	if ds.cval != nil {
-		C.OGR_DS_Destroy(ds.cval)
+		dataset := ds.ToDataset()
+		dataset.Close()
 		ds.cval = nil
 	}
 }
 
 // Fetch the name of the data source
 func (ds DataSource) Name() string {
-	name := C.OGR_DS_GetName(ds.cval)
+	name := C.GDALGetDescription((C.GDALMajorObjectH)(ds.cval))
 	return C.GoString(name)
 }
 
 // Fetch the number of layers in this data source
 func (ds DataSource) LayerCount() int {
-	count := C.OGR_DS_GetLayerCount(ds.cval)
-	return int(count)
+	return ds.ToDataset().LayerCount()
 }
 
 // Fetch a layer of this data source by index
 func (ds DataSource) LayerByIndex(index int) Layer {
-	layer := C.OGR_DS_GetLayer(ds.cval, C.int(index))
-	return Layer{layer}
+	return ds.ToDataset().LayerByIndex(index)
 }
 
 // Fetch a layer of this data source by name
 func (ds DataSource) LayerByName(name string) Layer {
-	cString := C.CString(name)
-	defer C.free(unsafe.Pointer(cString))
-	layer := C.OGR_DS_GetLayerByName(ds.cval, cString)
-	return Layer{layer}
+	layer, _ := ds.ToDataset().LayerByName(name)
+	return layer
 }
 
 // Delete the layer from the data source
 func (ds DataSource) Delete(index int) error {
-	return OGRErr(C.OGR_DS_DeleteLayer(ds.cval, C.int(index))).Err()
+	return ds.ToDataset().DeleteLayer(index)
 }
 
 // Fetch the driver that the data source was opened with
 func (ds DataSource) Driver() OGRDriver {
-	driver := C.OGR_DS_GetDriver(ds.cval)
-	return OGRDriver{driver}
+	driver := C.GDALGetDatasetDriver((C.GDALDatasetH)(ds.cval))
+	return OGRDriver{(C.OGRSFDriverH)(driver)}
 }
 
 // Create a new layer on the data source
@@ -1658,25 +1985,8 @@ func (ds DataSource) CreateLayer(
 	geomType GeometryType,
 	options []string,
 ) Layer {
-	cName := C.CString(name)
-	defer C.free(unsafe.Pointer(cName))
-
-	length := len(options)
-	opts := make([]*C.char, length+1)
-	for i := 0; i < length; i++ {
-		opts[i] = C.CString(options[i])
-		defer C.free(unsafe.Pointer(opts[i]))
-	}
-	opts[length] = (*C.char)(unsafe.Pointer(nil))
-
-	layer := C.OGR_DS_CreateLayer(
-		ds.cval,
-		cName,
-		sr.cval,
-		C.OGRwkbGeometryType(geomType),
-		(**C.char)(unsafe.Pointer(&opts[0])),
-	)
-	return Layer{layer}
+	layer, _ := ds.ToDataset().CreateLayer(name, sr, geomType, options)
+	return layer
 }
 
 // Duplicate an existing layer
@@ -1685,56 +1995,32 @@ func (ds DataSource) CopyLayer(
 	name string,
 	options []string,
 ) Layer {
-	cName := C.CString(name)
-	defer C.free(unsafe.Pointer(cName))
-
-	length := len(options)
-	opts := make([]*C.char, length+1)
-	for i := 0; i < length; i++ {
-		opts[i] = C.CString(options[i])
-		defer C.free(unsafe.Pointer(opts[i]))
-	}
-	opts[length] = (*C.char)(unsafe.Pointer(nil))
-
-	layer := C.OGR_DS_CopyLayer(
-		ds.cval,
-		source.cval,
-		cName,
-		(**C.char)(unsafe.Pointer(&opts[0])),
-	)
-	return Layer{layer}
+	layer, _ := ds.ToDataset().CopyLayer(source, name, options)
+	return layer
 }
 
 // Test if the data source has the indicated capability
 func (ds DataSource) TestCapability(capability string) bool {
-	cString := C.CString(capability)
-	defer C.free(unsafe.Pointer(cString))
-	val := C.OGR_DS_TestCapability(ds.cval, cString)
-	return val != 0
+	return ds.ToDataset().TestCapability(capability)
 }
 
 // Execute an SQL statement against the data source
 func (ds DataSource) ExecuteSQL(sql string, filter Geometry, dialect string) Layer {
-	cSQL := C.CString(sql)
-	defer C.free(unsafe.Pointer(cSQL))
-	cDialect := C.CString(dialect)
-	defer C.free(unsafe.Pointer(cDialect))
-
-	layer := C.OGR_DS_ExecuteSQL(ds.cval, cSQL, filter.cval, cDialect)
-	return Layer{layer}
+	layer, _ := ds.ToDataset().ExecuteSQL(sql, filter, dialect)
+	return layer
 }
 
 // Release the results of ExecuteSQL
 func (ds DataSource) ReleaseResultSet(layer Layer) {
-	if ds.cval == nil {
-		return
-	}
-	C.OGR_DS_ReleaseResultSet(ds.cval, layer.cval)
+	ds.ToDataset().ReleaseResultSet(layer)
 }
 
 // Flush pending changes to the data source
 func (ds DataSource) Sync() error {
-	return OGRErr(C.OGR_DS_SyncToDisk(ds.cval)).Err()
+	if cplCall(ds.ToDataset().FlushCache).failed() {
+		return ErrFailure
+	}
+	return nil
 }
 
 /* -------------------------------------------------------------------- */
@@ -21,3 +21,149 @@ func TestMakeValid(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "MULTIPOLYGON (((0 1,0.5 0.5,0 0,0 1)),((1 0,0.5 0.5,1 1,1 0)))", wktOut)
 }
+
+func TestFieldDefinitionProperties(t *testing.T) {
+	fd := gdal.CreateFieldDefinition("flag", gdal.FT_Integer)
+	defer fd.Destroy()
+
+	assert.Equal(t, gdal.FST_None, fd.SubType())
+	fd.SetSubType(gdal.FST_Boolean)
+	assert.Equal(t, gdal.FST_Boolean, fd.SubType())
+	assert.Equal(t, "Boolean", fd.SubType().Name())
+
+	assert.True(t, fd.IsNullable())
+	fd.SetNullable(false)
+	assert.False(t, fd.IsNullable())
+
+	assert.False(t, fd.IsUnique())
+	fd.SetUnique(true)
+	assert.True(t, fd.IsUnique())
+
+	assert.Equal(t, "", fd.Default())
+	fd.SetDefault("1")
+	assert.Equal(t, "1", fd.Default())
+	assert.False(t, fd.IsDefaultDriverSpecific())
+	fd.SetDefault("")
+	assert.Equal(t, "", fd.Default())
+
+	if gdal.VERSION_NUM >= 3070000 {
+		assert.NoError(t, fd.SetComment("whether the feature is flagged"))
+		assert.Equal(t, "whether the feature is flagged", fd.Comment())
+	} else {
+		assert.Error(t, fd.SetComment("whether the feature is flagged"))
+		assert.Equal(t, "", fd.Comment())
+	}
+	fd.SetAlternativeName("Flag")
+	assert.Equal(t, "Flag", fd.AlternativeName())
+}
+
+func TestInteger64Fields(t *testing.T) {
+	ds := testVectorDataset(t)
+	defer ds.Close()
+	layer, err := ds.CreateLayer("big", gdal.SpatialReference{}, gdal.GT_None, nil)
+	assert.NoError(t, err)
+
+	for _, f := range []struct {
+		name string
+		ft   gdal.FieldType
+	}{{"big", gdal.FT_Integer64}, {"bigs", gdal.FT_Integer64List}, {"small", gdal.FT_IntegerList}} {
+		fd := gdal.CreateFieldDefinition(f.name, f.ft)
+		assert.NoError(t, layer.CreateField(fd, false))
+		fd.Destroy()
+	}
+
+	feature := layer.Definition().Create()
+	defer feature.Destroy()
+	assert.Equal(t, gdal.NullFID, feature.FID())
+	assert.NoError(t, feature.SetFID(1<<40))
+	feature.SetFieldInteger64(0, 1<<50)
+	feature.SetFieldInteger64List(1, []int64{1 << 33, -1})
+	feature.SetFieldIntegerList(2, []int{1, 2, 3})
+	assert.NoError(t, layer.Create(feature))
+
+	read := layer.Feature(1 << 40)
+	defer read.Destroy()
+	assert.Equal(t, int64(1<<40), read.FID())
+	assert.Equal(t, int64(1<<50), read.FieldAsInteger64(0))
+	assert.Equal(t, []int64{1 << 33, -1}, read.FieldAsInteger64List(1))
+	assert.Equal(t, []int{1, 2, 3}, read.FieldAsIntegerList(2))
+
+	assert.True(t, read.IsFieldSetAndNotNull(0))
+	read.SetFieldNull(0)
+	assert.True(t, read.IsFieldNull(0))
+	assert.True(t, read.IsFieldSet(0))
+	assert.False(t, read.IsFieldSetAndNotNull(0))
+}
+
+func TestGeometryFields(t *testing.T) {
+	ds := testVectorDataset(t)
+	defer ds.Close()
+	layer, err := ds.CreateLayer("buildings", gdal.SpatialReference{}, gdal.GT_None, nil)
+	assert.NoError(t, err)
+
+	wgs84 := gdal.CreateSpatialReference(nil)
+	defer wgs84.Release()
+	assert.NoError(t, wgs84.FromEPSG(4326))
+	mercator := gdal.CreateSpatialReference(nil)
+	defer mercator.Release()
+	assert.NoError(t, mercator.FromEPSG(3857))
+
+	footprint := gdal.CreateGeometryFieldDefinition("footprint", gdal.GT_Polygon)
+	footprint.SetSpatialReference(wgs84)
+	assert.NoError(t, layer.CreateGeomField(footprint, false))
+	footprint.Destroy()
+	centroid := gdal.CreateGeometryFieldDefinition("centroid", gdal.GT_Point)
+	centroid.SetSpatialReference(mercator)
+	centroid.SetNullable(false)
+	assert.NoError(t, layer.CreateGeomField(centroid, false))
+	centroid.Destroy()
+
+	def := layer.Definition()
+	assert.Equal(t, 2, def.GeomFieldCount())
+	assert.Equal(t, 1, def.GeomFieldIndex("centroid"))
+	assert.Equal(t, -1, def.GeomFieldIndex("missing"))
+	assert.Equal(t, "footprint", def.GeomFieldDefinition(0).Name())
+	assert.Equal(t, gdal.GT_Point, def.GeomFieldDefinition(1).Type())
+	assert.True(t, def.GeomFieldDefinition(0).SpatialReference().IsSame(wgs84))
+	assert.True(t, def.GeomFieldDefinition(1).SpatialReference().IsSame(mercator))
+	assert.True(t, def.GeomFieldDefinition(0).IsNullable())
+	assert.False(t, def.GeomFieldDefinition(1).IsNullable())
+
+	polygon, err := gdal.CreateFromWKT("POLYGON ((0 0,0 1,1 1,1 0,0 0))", wgs84)
+	assert.NoError(t, err)
+	defer polygon.Destroy()
+	point, err := gdal.CreateFromWKT("POINT (55000 55000)", mercator)
+	assert.NoError(t, err)
+	defer point.Destroy()
+
+	feature := def.Create()
+	defer feature.Destroy()
+	assert.Equal(t, 2, feature.GeomFieldCount())
+	assert.Equal(t, 1, feature.GeomFieldIndex("centroid"))
+	assert.Equal(t, "centroid", feature.GeomFieldDefinition(1).Name())
+	assert.NoError(t, feature.SetGeomField(0, polygon))
+	assert.NoError(t, feature.SetGeomField(1, point))
+	assert.NoError(t, layer.Create(feature))
+
+	layer.ResetReading()
+	read := layer.NextFeature()
+	wkt, err := read.GeomField(0).ToWKT()
+	assert.NoError(t, err)
+	assert.Equal(t, "POLYGON ((0 0,0 1,1 1,1 0,0 0))", wkt)
+	wkt, err = read.GeomField(1).ToWKT()
+	assert.NoError(t, err)
+	assert.Equal(t, "POINT (55000 55000)", wkt)
+	read.Destroy()
+
+	// the point lies outside the unit square once the filter applies to the centroid field
+	layer.SetSpatialFilterEx(1, polygon)
+	count, _ := layer.FeatureCount(true)
+	assert.Equal(t, 0, count)
+	layer.SetSpatialFilterRectEx(1, 50000, 50000, 60000, 60000)
+	count, _ = layer.FeatureCount(true)
+	assert.Equal(t, 1, count)
+	layer.SetSpatialFilterEx(0, polygon)
+	count, _ = layer.FeatureCount(true)
+	assert.Equal(t, 1, count)
+	layer.SetSpatialFilterEx(0, gdal.Geometry{})
+}
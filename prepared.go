@@ -0,0 +1,60 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Prepared geometries                                             */
+/* -------------------------------------------------------------------- */
+
+// PreparedGeometry is a geometry indexed by GEOS for repeated predicate tests against other geometries
+type PreparedGeometry struct {
+	cval C.OGRPreparedGeometryH
+}
+
+// HasPreparedGeometrySupport reports whether GDAL is built with GEOS support for prepared geometries
+func HasPreparedGeometrySupport() bool {
+	return C.OGRHasPreparedGeometrySupport() != 0
+}
+
+// Prepare indexes the geometry for repeated Contains and Intersects tests. The prepared geometry refers to geom,
+// which must not be modified or destroyed before the prepared geometry is.
+func (geom Geometry) Prepare() (PreparedGeometry, error) {
+	if !HasPreparedGeometrySupport() {
+		return PreparedGeometry{nil}, fmt.Errorf("Error: prepared geometries require GDAL built with GEOS")
+	}
+	prepared := C.OGRCreatePreparedGeometry(geom.cval)
+	if prepared == nil {
+		return PreparedGeometry{nil}, fmt.Errorf("Error: geometry cannot be prepared")
+	}
+	return PreparedGeometry{prepared}, nil
+}
+
+// Destroy the prepared geometry
+func (prepared *PreparedGeometry) Destroy() {
+	if prepared.cval != nil {
+		C.OGRDestroyPreparedGeometry(prepared.cval)
+		prepared.cval = nil
+	}
+}
+
+// Return true if the prepared geometry contains the other
+func (prepared PreparedGeometry) Contains(other Geometry) bool {
+	return C.OGRPreparedGeometryContains(prepared.cval, other.cval) != 0
+}
+
+// Return true if the prepared geometry intersects the other
+func (prepared PreparedGeometry) Intersects(other Geometry) bool {
+	return C.OGRPreparedGeometryIntersects(prepared.cval, other.cval) != 0
+}
@@ -0,0 +1,178 @@
+package gdal
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Spatial index                                                   */
+/* -------------------------------------------------------------------- */
+
+// defaultNodeCapacity is the number of children per node of a SpatialIndex when none is given
+const defaultNodeCapacity = 10
+
+// spatialRect is the bounding box of an entry or node of a SpatialIndex
+type spatialRect struct {
+	minX, minY, maxX, maxY float64
+}
+
+func envelopeRect(env Envelope) spatialRect {
+	return spatialRect{env.MinX(), env.MinY(), env.MaxX(), env.MaxY()}
+}
+
+func (r spatialRect) intersects(other spatialRect) bool {
+	return r.minX <= other.maxX && r.maxX >= other.minX && r.minY <= other.maxY && r.maxY >= other.minY
+}
+
+func (r spatialRect) union(other spatialRect) spatialRect {
+	return spatialRect{
+		math.Min(r.minX, other.minX), math.Min(r.minY, other.minY),
+		math.Max(r.maxX, other.maxX), math.Max(r.maxY, other.maxY),
+	}
+}
+
+// spatialNode is an entry of a SpatialIndex when it has no children, and an inner node otherwise
+type spatialNode struct {
+	rect     spatialRect
+	id       int64
+	children []*spatialNode
+}
+
+// SpatialIndex is an STR-tree of identifiers keyed by their envelope. It is built from all inserted entries on
+// the first query after an insertion; once built, it can be queried from several goroutines, but Insert must not
+// be called concurrently with other methods.
+type SpatialIndex struct {
+	capacity int
+	entries  []*spatialNode
+	mu       sync.Mutex
+	root     *spatialNode
+	built    bool
+}
+
+// NewSpatialIndex creates an empty index whose nodes have up to capacity children, or 10 if capacity is below 2
+func NewSpatialIndex(capacity int) *SpatialIndex {
+	if capacity < 2 {
+		capacity = defaultNodeCapacity
+	}
+	return &SpatialIndex{capacity: capacity}
+}
+
+// Insert adds id to the index with the envelope env
+func (idx *SpatialIndex) Insert(env Envelope, id int64) {
+	idx.entries = append(idx.entries, &spatialNode{rect: envelopeRect(env), id: id})
+	idx.built = false
+}
+
+// Len returns the number of entries in the index
+func (idx *SpatialIndex) Len() int {
+	return len(idx.entries)
+}
+
+// Build packs the entries into the tree. Queries call it as needed, but calling it after the last insertion
+// avoids building the tree during the first query.
+func (idx *SpatialIndex) Build() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.built {
+		return
+	}
+	idx.root = nil
+	if len(idx.entries) > 0 {
+		level := idx.pack(append([]*spatialNode(nil), idx.entries...))
+		for len(level) > 1 {
+			level = idx.pack(level)
+		}
+		idx.root = level[0]
+	}
+	idx.built = true
+}
+
+// pack groups nodes into parents by Sort-Tile-Recursive: nodes are sorted into vertical slices by the x of their
+// center, then each slice is sorted by the y of their center and cut into parents
+func (idx *SpatialIndex) pack(nodes []*spatialNode) []*spatialNode {
+	parentCount := (len(nodes) + idx.capacity - 1) / idx.capacity
+	sliceCount := int(math.Ceil(math.Sqrt(float64(parentCount))))
+	sliceSize := sliceCount * idx.capacity
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].rect.minX+nodes[i].rect.maxX < nodes[j].rect.minX+nodes[j].rect.maxX
+	})
+	parents := make([]*spatialNode, 0, parentCount)
+	for start := 0; start < len(nodes); start += sliceSize {
+		end := start + sliceSize
+		if end > len(nodes) {
+			end = len(nodes)
+		}
+		slice := nodes[start:end]
+		sort.Slice(slice, func(i, j int) bool {
+			return slice[i].rect.minY+slice[i].rect.maxY < slice[j].rect.minY+slice[j].rect.maxY
+		})
+		for first := 0; first < len(slice); first += idx.capacity {
+			last := first + idx.capacity
+			if last > len(slice) {
+				last = len(slice)
+			}
+			children := slice[first:last]
+			parent := &spatialNode{rect: children[0].rect, children: children}
+			for _, child := range children[1:] {
+				parent.rect = parent.rect.union(child.rect)
+			}
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
+
+// Query returns the identifiers whose envelope intersects env, in no particular order
+func (idx *SpatialIndex) Query(env Envelope) []int64 {
+	idx.Build()
+	if idx.root == nil {
+		return nil
+	}
+	rect := envelopeRect(env)
+	var ids []int64
+	stack := []*spatialNode{idx.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !node.rect.intersects(rect) {
+			continue
+		}
+		if node.children == nil {
+			ids = append(ids, node.id)
+			continue
+		}
+		stack = append(stack, node.children...)
+	}
+	return ids
+}
+
+// QueryGeometry returns the identifiers whose envelope intersects the envelope of geom. These are candidates,
+// which should be tested against geom with an exact predicate.
+func (idx *SpatialIndex) QueryGeometry(geom Geometry) []int64 {
+	if geom.IsEmpty() {
+		return nil
+	}
+	return idx.Query(geom.Envelope())
+}
+
+// SpatialIndex builds an index of the envelopes of the features of the layer that pass its filters, keyed by
+// feature identifier. Features without geometry are left out.
+func (layer Layer) SpatialIndex(ctx context.Context) (*SpatialIndex, error) {
+	idx := NewSpatialIndex(0)
+	for feature, err := range layer.Features(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		geom := feature.Geometry()
+		if geom.IsEmpty() {
+			continue
+		}
+		idx.Insert(geom.Envelope(), feature.FID())
+	}
+	idx.Build()
+	return idx, nil
+}
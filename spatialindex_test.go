@@ -0,0 +1,73 @@
+package gdal_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreparedGeometry(t *testing.T) {
+	polygon, err := gdal.CreateFromWKT("POLYGON ((0 0,0 10,10 10,10 0,0 0))", gdal.SpatialReference{})
+	assert.NoError(t, err)
+	defer polygon.Destroy()
+	inside, err := gdal.CreateFromWKT("POINT (5 5)", gdal.SpatialReference{})
+	assert.NoError(t, err)
+	defer inside.Destroy()
+	outside, err := gdal.CreateFromWKT("POINT (15 5)", gdal.SpatialReference{})
+	assert.NoError(t, err)
+	defer outside.Destroy()
+
+	assert.True(t, gdal.HasPreparedGeometrySupport())
+	prepared, err := polygon.Prepare()
+	assert.NoError(t, err)
+	defer prepared.Destroy()
+	assert.True(t, prepared.Contains(inside))
+	assert.True(t, prepared.Intersects(inside))
+	assert.False(t, prepared.Contains(outside))
+	assert.False(t, prepared.Intersects(outside))
+}
+
+func TestSpatialIndex(t *testing.T) {
+	idx := gdal.NewSpatialIndex(4)
+	assert.Empty(t, idx.Query(gdal.NewEnvelope(0, 0, 100, 100)))
+
+	// a 10x10 grid of unit squares, identified by 10*row+column
+	for row := 0; row < 10; row++ {
+		for col := 0; col < 10; col++ {
+			env := gdal.NewEnvelope(float64(col), float64(row), float64(col)+1, float64(row)+1)
+			idx.Insert(env, int64(10*row+col))
+		}
+	}
+	assert.Equal(t, 100, idx.Len())
+
+	ids := idx.Query(gdal.NewEnvelope(2.5, 3.5, 3.5, 3.75))
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	assert.Equal(t, []int64{32, 33}, ids)
+	assert.Len(t, idx.Query(gdal.NewEnvelope(-1, -1, 100, 100)), 100)
+	assert.Empty(t, idx.Query(gdal.NewEnvelope(20, 20, 30, 30)))
+
+	point, err := gdal.CreateFromWKT("POINT (9.5 0.5)", gdal.SpatialReference{})
+	assert.NoError(t, err)
+	defer point.Destroy()
+	assert.Equal(t, []int64{9}, idx.QueryGeometry(point))
+
+	// inserting after a query rebuilds the tree
+	idx.Insert(gdal.NewEnvelope(50, 50, 51, 51), 1000)
+	assert.Equal(t, []int64{1000}, idx.Query(gdal.NewEnvelope(49, 49, 52, 52)))
+}
+
+func TestLayerSpatialIndex(t *testing.T) {
+	ds := testVectorDataset(t)
+	defer ds.Close()
+	layer := testWKTLayer(t, ds, "points", "POINT (1 1)", "POINT (5 5)", "", "POINT (9 9)")
+
+	idx, err := layer.SpatialIndex(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, idx.Len())
+	ids := idx.Query(gdal.NewEnvelope(0, 0, 6, 6))
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	assert.Equal(t, []int64{0, 1}, ids)
+}
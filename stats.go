@@ -0,0 +1,120 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Network and cache statistics                                    */
+/* -------------------------------------------------------------------- */
+
+// NetworkMethodStats counts the requests of one HTTP method
+type NetworkMethodStats struct {
+	Count           int64 `json:"count"`
+	DownloadedBytes int64 `json:"downloaded_bytes,omitempty"`
+	UploadedBytes   int64 `json:"uploaded_bytes,omitempty"`
+}
+
+// NetworkActionStats counts the requests issued by one VSI action, such as Read or Stat
+type NetworkActionStats struct {
+	Methods map[string]NetworkMethodStats `json:"methods,omitempty"`
+}
+
+// NetworkFileStats counts the requests issued for one file
+type NetworkFileStats struct {
+	Methods map[string]NetworkMethodStats `json:"methods,omitempty"`
+	Actions map[string]NetworkActionStats `json:"actions,omitempty"`
+}
+
+// NetworkHandlerStats counts the requests issued by one network file system, such as vsis3 or vsicurl
+type NetworkHandlerStats struct {
+	Methods map[string]NetworkMethodStats `json:"methods,omitempty"`
+	Files   map[string]NetworkFileStats   `json:"files,omitempty"`
+}
+
+// NetworkStatistics holds the HTTP requests issued by the network file systems since the last reset
+type NetworkStatistics struct {
+	Methods  map[string]NetworkMethodStats  `json:"methods,omitempty"`
+	Handlers map[string]NetworkHandlerStats `json:"handlers,omitempty"`
+}
+
+// Total sums the requests of all methods
+func (stats NetworkStatistics) Total() NetworkMethodStats {
+	var total NetworkMethodStats
+	for _, m := range stats.Methods {
+		total.Count += m.Count
+		total.DownloadedBytes += m.DownloadedBytes
+		total.UploadedBytes += m.UploadedBytes
+	}
+	return total
+}
+
+// EnableNetworkStats turns on the collection of network statistics. It must be called before the file systems are
+// first used.
+func EnableNetworkStats(enable bool) {
+	if enable {
+		SetConfigOption("CPL_VSIL_NETWORK_STATS_ENABLED", "YES")
+	} else {
+		SetConfigOption("CPL_VSIL_NETWORK_STATS_ENABLED", "NO")
+	}
+}
+
+// NetworkStats returns the network statistics collected since the last ResetNetworkStats
+func NetworkStats() (NetworkStatistics, error) {
+	var stats NetworkStatistics
+
+	p := C.VSINetworkStatsGetAsSerializedJSON(nil)
+	if p == nil {
+		return stats, fmt.Errorf("Error: network statistics are not available")
+	}
+	defer C.VSIFree(unsafe.Pointer(p))
+
+	if err := json.Unmarshal([]byte(C.GoString(p)), &stats); err != nil {
+		return stats, fmt.Errorf("Error: parsing network statistics: %w", err)
+	}
+	return stats, nil
+}
+
+// ResetNetworkStats clears the network statistics
+func ResetNetworkStats() {
+	C.VSINetworkStatsReset()
+}
+
+// CacheStats holds the state of the raster block cache, in bytes
+type CacheStats struct {
+	Used int64 `json:"used"`
+	Max  int64 `json:"max"`
+}
+
+// GetCacheStats returns the memory used by and allowed to the raster block cache
+func GetCacheStats() CacheStats {
+	return CacheStats{
+		Used: int64(C.GDALGetCacheUsed64()),
+		Max:  int64(C.GDALGetCacheMax64()),
+	}
+}
+
+// PublishExpvar publishes the cache and network statistics as an expvar variable named name, served as JSON by
+// the expvar handler. It panics if name is already published, as expvar.Publish does.
+func PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		vars := map[string]interface{}{"cache": GetCacheStats()}
+		if network, err := NetworkStats(); err == nil {
+			vars["network"] = network
+		}
+		return vars
+	}))
+}
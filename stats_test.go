@@ -0,0 +1,54 @@
+package gdal_test
+
+import (
+	"bytes"
+	"expvar"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// must be set before the network file systems are first used
+	gdal.EnableNetworkStats(true)
+}
+
+func TestNetworkStats(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "stats.bin", time.Unix(0, 0), bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	gdal.ResetNetworkStats()
+	f, err := gdal.VSIFOpenL("/vsicurl/"+server.URL+"/stats.bin", "rb")
+	assert.NoError(t, err)
+	data, err := io.ReadAll(f)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	assert.Equal(t, content, data)
+
+	stats, err := gdal.NetworkStats()
+	assert.NoError(t, err)
+	total := stats.Total()
+	assert.Greater(t, total.Count, int64(0))
+	assert.GreaterOrEqual(t, total.DownloadedBytes, int64(len(content)))
+	assert.Contains(t, stats.Handlers, "vsicurl")
+
+	gdal.ResetNetworkStats()
+	stats, err = gdal.NetworkStats()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), stats.Total().Count)
+
+	cache := gdal.GetCacheStats()
+	assert.Equal(t, int64(gdal.GetCacheMax()), cache.Max)
+	assert.GreaterOrEqual(t, cache.Used, int64(0))
+
+	gdal.PublishExpvar("gdal_test")
+	assert.Contains(t, expvar.Get("gdal_test").String(), `"cache"`)
+}
@@ -0,0 +1,97 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"io"
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Streaming through /vsistdout/ and /vsisubfile/                  */
+/* -------------------------------------------------------------------- */
+
+type stdoutWriter struct {
+	w   io.Writer
+	err error
+}
+
+// vsiStdoutLock serializes redirections, as /vsistdout/ is process wide
+var vsiStdoutLock sync.Mutex
+
+//export goVSIStdoutWriteA
+func goVSIStdoutWriteA(handle C.uintptr_t, p unsafe.Pointer, n C.size_t) C.size_t {
+	sw := cgo.Handle(handle).Value().(*stdoutWriter)
+	if sw.err != nil || n == 0 {
+		return 0
+	}
+	written, err := sw.w.Write(unsafe.Slice((*byte)(p), int(n)))
+	if err != nil {
+		sw.err = err
+	}
+	return C.size_t(written)
+}
+
+// VSIStdoutRedirect sends everything written to /vsistdout/ to w, until the returned function is called. That
+// function returns the first error returned by w. /vsistdout/ is shared by the whole process, so concurrent
+// redirections wait for the previous one to be released.
+func VSIStdoutRedirect(w io.Writer) (release func() error) {
+	vsiStdoutLock.Lock()
+
+	sw := &stdoutWriter{w: w}
+	handle := cgo.NewHandle(sw)
+	C.goVSIStdoutSetRedirection(C.uintptr_t(handle))
+
+	var once sync.Once
+	return func() error {
+		once.Do(func() {
+			C.goVSIStdoutResetRedirection()
+			handle.Delete()
+			vsiStdoutLock.Unlock()
+		})
+		return sw.err
+	}
+}
+
+// CreateCopyToWriter encodes src with this driver and streams the result to w through /vsistdout/. Only drivers
+// able to write sequentially, such as GeoJSON, CSV or GTiff with STREAMABLE_OUTPUT=YES, are supported.
+func (driver Driver) CreateCopyToWriter(w io.Writer, src Dataset, options []string) error {
+	release := VSIStdoutRedirect(w)
+
+	ds := driver.CreateCopy("/vsistdout/", src, 0, options, nil, nil)
+	failed := ds.cval == nil
+	ds.Close()
+
+	if err := release(); err != nil {
+		return err
+	}
+	if failed {
+		return fmt.Errorf("Error: %s copy to writer failed", driver.ShortName())
+	}
+	return nil
+}
+
+// VSISubfilePath returns the /vsisubfile/ path exposing size bytes of path starting at offset. A size of 0 extends
+// to the end of the file.
+func VSISubfilePath(path string, offset, size int64) string {
+	return fmt.Sprintf("/vsisubfile/%d_%d,%s", offset, size, path)
+}
+
+// OpenRange opens, read-only, a raster or vector dataset embedded in a larger file, at offset and of size bytes
+func OpenRange(path string, offset, size int64) (Dataset, error) {
+	if offset < 0 || size < 0 {
+		return Dataset{nil}, fmt.Errorf("Error: invalid range %d+%d of '%s'", offset, size, path)
+	}
+	return OpenEx(VSISubfilePath(path, offset, size), GDALOFReadOnly|GDALOFRaster|GDALOFVector, nil, nil, nil)
+}
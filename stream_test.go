@@ -0,0 +1,75 @@
+package gdal_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+const testGeoJSON = `{"type": "FeatureCollection", "features": [
+{"type": "Feature", "properties": {"name": "a", "value": 1}, "geometry": {"type": "Point", "coordinates": [1.0, 2.0]}},
+{"type": "Feature", "properties": {"name": "b", "value": 2}, "geometry": {"type": "Point", "coordinates": [3.0, 4.0]}}
+]}`
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestCreateCopyToWriter(t *testing.T) {
+	src, err := gdal.OpenBytes([]byte(testGeoJSON), gdal.GDALOFReadOnly|gdal.GDALOFVector, nil, nil)
+	assert.NoError(t, err)
+	defer src.Close()
+
+	driver, err := gdal.GetDriverByName("GeoJSON")
+	assert.NoError(t, err)
+
+	expected, err := driver.CreateCopyToBytes(src, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(expected), `"name": "b"`)
+
+	var buf bytes.Buffer
+	release := gdal.VSIStdoutRedirect(&buf)
+	out := driver.CreateCopy("/vsistdout/", src, 0, nil, nil, nil)
+	out.Close()
+	assert.NoError(t, release())
+	assert.NoError(t, release())
+	assert.Equal(t, expected, buf.Bytes())
+
+	recorder := httptest.NewRecorder()
+	assert.NoError(t, driver.CreateCopyToWriter(recorder, src, nil))
+	assert.Equal(t, expected, recorder.Body.Bytes())
+
+	assert.Error(t, driver.CreateCopyToWriter(failingWriter{}, src, nil))
+}
+
+func TestOpenRange(t *testing.T) {
+	ds := testDataset(t)
+	defer ds.Close()
+	driver, err := gdal.GetDriverByName("GTiff")
+	assert.NoError(t, err)
+	tiff, err := driver.CreateCopyToBytes(ds, nil)
+	assert.NoError(t, err)
+
+	header := []byte("custom container header")
+	name := "/vsimem/test_openrange.bin"
+	writeVSIFile(t, name, append(append(header, tiff...), []byte("trailer")...))
+	defer gdal.VSIUnlink(name)
+
+	embedded, err := gdal.OpenRange(name, int64(len(header)), int64(len(tiff)))
+	assert.NoError(t, err)
+	defer embedded.Close()
+	assert.Equal(t, ds.RasterXSize(), embedded.RasterXSize())
+	assert.Equal(t, ds.GeoTransform(), embedded.GeoTransform())
+
+	_, err = gdal.OpenRange(name, 0, int64(len(header)))
+	assert.Error(t, err)
+	_, err = gdal.OpenRange(name, -1, 10)
+	assert.Error(t, err)
+	assert.Equal(t, "/vsisubfile/10_20,/vsimem/x", gdal.VSISubfilePath("/vsimem/x", 10, 20))
+}
@@ -0,0 +1,540 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Struct tag marshalling                                          */
+/* -------------------------------------------------------------------- */
+
+// Features are decoded into and encoded from Go structs. Struct fields are mapped to feature fields by the name
+// in their `ogr:"name"` tag, or by their own name when untagged. A tag of "-" skips the field. The options
+// ",geometry" and ",fid" map a field to the feature geometry, which must be a Geometry, or to the feature
+// identifier, which must be an integer; a Geometry field is the geometry even without option. Pointer fields are
+// nil for null or unset feature fields and write null when nil.
+//
+// Go types map to field types as follows:
+//
+//	bool                              Integer, Boolean subtype
+//	int8, int16, uint8                Integer, Int16 subtype
+//	int32, uint16                     Integer
+//	int, int64, uint, uint32, uint64  Integer64
+//	float32                           Real, Float32 subtype
+//	float64                           Real
+//	string                            String
+//	[]byte                            Binary
+//	time.Time                         DateTime
+//	[]int32                           IntegerList
+//	[]int, []int64                    Integer64List
+//	[]float64                         RealList
+//	[]string                          StringList
+
+var (
+	geometryType = reflect.TypeOf(Geometry{})
+	timeType     = reflect.TypeOf(time.Time{})
+	bytesType    = reflect.TypeOf([]byte(nil))
+)
+
+const (
+	structField = iota
+	structGeometry
+	structFID
+)
+
+type structFieldInfo struct {
+	index     int
+	name      string
+	role      int
+	fieldType FieldType
+	subType   FieldSubType
+	nullable  bool
+}
+
+var structInfos sync.Map // reflect.Type -> []structFieldInfo
+
+// fieldTypeOf returns the feature field type and subtype a Go type is stored as
+func fieldTypeOf(t reflect.Type) (FieldType, FieldSubType, bool) {
+	switch t {
+	case timeType:
+		return FT_DateTime, FST_None, true
+	case bytesType:
+		return FT_Binary, FST_None, true
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return FT_Integer, FST_Boolean, true
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return FT_Integer, FST_Int16, true
+	case reflect.Int32, reflect.Uint16:
+		return FT_Integer, FST_None, true
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return FT_Integer64, FST_None, true
+	case reflect.Float32:
+		return FT_Real, FST_Float32, true
+	case reflect.Float64:
+		return FT_Real, FST_None, true
+	case reflect.String:
+		return FT_String, FST_None, true
+	case reflect.Slice:
+		switch t.Elem().Kind() {
+		case reflect.Int32:
+			return FT_IntegerList, FST_None, true
+		case reflect.Int, reflect.Int64:
+			return FT_Integer64List, FST_None, true
+		case reflect.Float64:
+			return FT_RealList, FST_None, true
+		case reflect.String:
+			return FT_StringList, FST_None, true
+		}
+	}
+	return 0, FST_None, false
+}
+
+func isIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// structInfo returns the mapping of the fields of struct type t, which is computed once per type
+func structInfo(t reflect.Type) ([]structFieldInfo, error) {
+	if infos, ok := structInfos.Load(t); ok {
+		return infos.([]structFieldInfo), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Error: %s is not a struct", t)
+	}
+
+	var infos []structFieldInfo
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("ogr")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		info := structFieldInfo{index: i, name: name}
+
+		switch {
+		case options == "geometry" || f.Type == geometryType:
+			if f.Type != geometryType {
+				return nil, fmt.Errorf("Error: geometry field '%s' of %s must be a Geometry", f.Name, t)
+			}
+			info.role = structGeometry
+		case options == "fid":
+			if !isIntegerKind(f.Type.Kind()) {
+				return nil, fmt.Errorf("Error: fid field '%s' of %s must be an integer", f.Name, t)
+			}
+			info.role = structFID
+		case options != "":
+			return nil, fmt.Errorf("Error: field '%s' of %s has unknown ogr option '%s'", f.Name, t, options)
+		default:
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				info.nullable = true
+				ft = ft.Elem()
+			}
+			var ok bool
+			info.fieldType, info.subType, ok = fieldTypeOf(ft)
+			if !ok {
+				return nil, fmt.Errorf("Error: field '%s' of %s has unsupported type %s", f.Name, t, f.Type)
+			}
+		}
+		infos = append(infos, info)
+	}
+
+	structInfos.Store(t, infos)
+	return infos, nil
+}
+
+// structValue returns the struct v points to, or v itself when it is a struct and settable is false
+func structValue(v interface{}, settable bool) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	} else if settable {
+		return rv, fmt.Errorf("Error: decoding into %T, which is not a non-nil struct pointer", v)
+	}
+	if rv.Kind() != reflect.Struct {
+		return rv, fmt.Errorf("Error: %T is not a struct", v)
+	}
+	return rv, nil
+}
+
+// Decode copies the fields, geometry and identifier of the feature into the struct v points to, as mapped by its
+// ogr tags. Struct fields missing from the feature are left untouched. The geometry is a copy owned by v, which
+// must be destroyed.
+func (feature Feature) Decode(v interface{}) error {
+	rv, err := structValue(v, true)
+	if err != nil {
+		return err
+	}
+	infos, err := structInfo(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		field := rv.Field(info.index)
+		switch info.role {
+		case structGeometry:
+			geom := C.OGR_F_GetGeometryRef(feature.cval)
+			if geom != nil {
+				geom = C.OGR_G_Clone(geom)
+			}
+			field.Set(reflect.ValueOf(Geometry{geom}))
+		case structFID:
+			setInteger(field, int64(C.OGR_F_GetFID(feature.cval)))
+		default:
+			index := feature.FieldIndex(info.name)
+			if index < 0 {
+				continue
+			}
+			if C.OGR_F_IsFieldSetAndNotNull(feature.cval, C.int(index)) == 0 {
+				field.Set(reflect.Zero(field.Type()))
+				continue
+			}
+			if info.nullable {
+				field.Set(reflect.New(field.Type().Elem()))
+				field = field.Elem()
+			}
+			feature.decodeField(index, field)
+		}
+	}
+	return nil
+}
+
+func setInteger(field reflect.Value, value int64) {
+	if field.CanInt() {
+		field.SetInt(value)
+	} else {
+		field.SetUint(uint64(value))
+	}
+}
+
+// decodeField stores the field at index, which is set and not null, into field
+func (feature Feature) decodeField(index int, field reflect.Value) {
+	cIndex := C.int(index)
+	switch field.Type() {
+	case timeType:
+		t, _ := feature.fieldAsTime(index)
+		field.Set(reflect.ValueOf(t))
+		return
+	case bytesType:
+		var count C.int
+		p := C.OGR_F_GetFieldAsBinary(feature.cval, cIndex, &count)
+		field.SetBytes(C.GoBytes(unsafe.Pointer(p), count))
+		return
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		field.SetBool(C.OGR_F_GetFieldAsInteger(feature.cval, cIndex) != 0)
+	case reflect.Float32, reflect.Float64:
+		field.SetFloat(float64(C.OGR_F_GetFieldAsDouble(feature.cval, cIndex)))
+	case reflect.String:
+		field.SetString(C.GoString(C.OGR_F_GetFieldAsString(feature.cval, cIndex)))
+	case reflect.Slice:
+		var count C.int
+		switch field.Type().Elem().Kind() {
+		case reflect.Int32:
+			p := C.OGR_F_GetFieldAsIntegerList(feature.cval, cIndex, &count)
+			list := make([]int32, int(count))
+			for i, x := range unsafe.Slice(p, int(count)) {
+				list[i] = int32(x)
+			}
+			field.Set(reflect.ValueOf(list).Convert(field.Type()))
+		case reflect.Int, reflect.Int64:
+			p := C.OGR_F_GetFieldAsInteger64List(feature.cval, cIndex, &count)
+			list := reflect.MakeSlice(field.Type(), int(count), int(count))
+			for i, x := range unsafe.Slice(p, int(count)) {
+				list.Index(i).SetInt(int64(x))
+			}
+			field.Set(list)
+		case reflect.Float64:
+			p := C.OGR_F_GetFieldAsDoubleList(feature.cval, cIndex, &count)
+			list := make([]float64, int(count))
+			for i, x := range unsafe.Slice(p, int(count)) {
+				list[i] = float64(x)
+			}
+			field.Set(reflect.ValueOf(list).Convert(field.Type()))
+		case reflect.String:
+			list := cStringList(C.OGR_F_GetFieldAsStringList(feature.cval, cIndex))
+			field.Set(reflect.ValueOf(list).Convert(field.Type()))
+		}
+	default:
+		setInteger(field, int64(C.OGR_F_GetFieldAsInteger64(feature.cval, cIndex)))
+	}
+}
+
+// fieldAsTime fetches a date, time or date and time field, in its time zone if it has one and in UTC otherwise,
+// along with its time zone flag
+func (feature Feature) fieldAsTime(index int) (time.Time, int) {
+	var year, month, day, hour, minute, tzFlag C.int
+	var second C.float
+	C.OGR_F_GetFieldAsDateTimeEx(feature.cval, C.int(index), &year, &month, &day, &hour, &minute, &second, &tzFlag)
+
+	// the time zone flag is 0 for unknown, 1 for local time and 100 plus the offset in quarter hours otherwise
+	loc := time.UTC
+	switch {
+	case tzFlag == 1:
+		loc = time.Local
+	case tzFlag > 1 && tzFlag != 100:
+		loc = time.FixedZone("", int(tzFlag-100)*15*60)
+	}
+	whole, frac := math.Modf(float64(second))
+	return time.Date(
+		int(year), time.Month(month), int(day), int(hour), int(minute), int(whole),
+		int(math.Round(frac*1000))*int(time.Millisecond), loc,
+	), int(tzFlag)
+}
+
+// Encode sets the fields, geometry and identifier of the feature from v, a struct or a pointer to one, as mapped
+// by its ogr tags. Struct fields missing from the feature are ignored, and a nil geometry and a zero identifier are
+// left unset, so that the layer assigns one. The geometry is copied. Unsigned values above math.MaxInt64 are
+// rejected, as they do not fit in Integer64 fields.
+func (feature Feature) Encode(v interface{}) error {
+	rv, err := structValue(v, false)
+	if err != nil {
+		return err
+	}
+	infos, err := structInfo(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		field := rv.Field(info.index)
+		switch info.role {
+		case structGeometry:
+			geom := field.Interface().(Geometry)
+			if geom.cval != nil {
+				if err := feature.SetGeometry(geom); err != nil {
+					return err
+				}
+			}
+		case structFID:
+			var fid int64
+			if field.CanInt() {
+				fid = field.Int()
+			} else {
+				if field.Uint() > math.MaxInt64 {
+					return fmt.Errorf("Error: fid %d overflows Integer64", field.Uint())
+				}
+				fid = int64(field.Uint())
+			}
+			if fid == 0 {
+				continue
+			}
+			if err := OGRErr(C.OGR_F_SetFID(feature.cval, C.GIntBig(fid))).Err(); err != nil {
+				return err
+			}
+		default:
+			index := feature.FieldIndex(info.name)
+			if index < 0 {
+				continue
+			}
+			if info.nullable {
+				if field.IsNil() {
+					C.OGR_F_SetFieldNull(feature.cval, C.int(index))
+					continue
+				}
+				field = field.Elem()
+			}
+			if err := feature.encodeField(index, field); err != nil {
+				return fmt.Errorf("Error: field '%s': %w", info.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// encodeField sets the field at index from field
+func (feature Feature) encodeField(index int, field reflect.Value) error {
+	cIndex := C.int(index)
+	switch field.Type() {
+	case timeType:
+		t := field.Interface().(time.Time)
+		_, offset := t.Zone()
+		C.OGR_F_SetFieldDateTimeEx(
+			feature.cval, cIndex,
+			C.int(t.Year()), C.int(t.Month()), C.int(t.Day()), C.int(t.Hour()), C.int(t.Minute()),
+			C.float(float64(t.Second())+float64(t.Nanosecond()/int(time.Millisecond))/1000),
+			C.int(100+offset/(15*60)),
+		)
+		return nil
+	case bytesType:
+		value := field.Bytes()
+		var p unsafe.Pointer
+		if len(value) > 0 {
+			p = unsafe.Pointer(&value[0])
+		}
+		C.OGR_F_SetFieldBinary(feature.cval, cIndex, C.int(len(value)), p)
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		C.OGR_F_SetFieldInteger(feature.cval, cIndex, BoolToCInt(field.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		C.OGR_F_SetFieldInteger64(feature.cval, cIndex, C.GIntBig(field.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if field.Uint() > math.MaxInt64 {
+			return fmt.Errorf("value %d overflows Integer64", field.Uint())
+		}
+		C.OGR_F_SetFieldInteger64(feature.cval, cIndex, C.GIntBig(field.Uint()))
+	case reflect.Float32, reflect.Float64:
+		C.OGR_F_SetFieldDouble(feature.cval, cIndex, C.double(field.Float()))
+	case reflect.String:
+		cValue := C.CString(field.String())
+		defer C.free(unsafe.Pointer(cValue))
+		C.OGR_F_SetFieldString(feature.cval, cIndex, cValue)
+	case reflect.Slice:
+		n := field.Len()
+		switch field.Type().Elem().Kind() {
+		case reflect.Int32:
+			list := make([]C.int, n+1)
+			for i := 0; i < n; i++ {
+				list[i] = C.int(field.Index(i).Int())
+			}
+			C.OGR_F_SetFieldIntegerList(feature.cval, cIndex, C.int(n), &list[0])
+		case reflect.Int, reflect.Int64:
+			list := make([]C.GIntBig, n+1)
+			for i := 0; i < n; i++ {
+				list[i] = C.GIntBig(field.Index(i).Int())
+			}
+			C.OGR_F_SetFieldInteger64List(feature.cval, cIndex, C.int(n), &list[0])
+		case reflect.Float64:
+			list := make([]C.double, n+1)
+			for i := 0; i < n; i++ {
+				list[i] = C.double(field.Index(i).Float())
+			}
+			C.OGR_F_SetFieldDoubleList(feature.cval, cIndex, C.int(n), &list[0])
+		case reflect.String:
+			list := make([]*C.char, n+1)
+			for i := 0; i < n; i++ {
+				list[i] = C.CString(field.Index(i).String())
+				defer C.free(unsafe.Pointer(list[i]))
+			}
+			list[n] = (*C.char)(unsafe.Pointer(nil))
+			C.OGR_F_SetFieldStringList(feature.cval, cIndex, (**C.char)(unsafe.Pointer(&list[0])))
+		}
+	}
+	return nil
+}
+
+// ReadAll decodes every feature of the layer that passes its filters, from the first one, into the slice of
+// structs v points to, replacing its content. The geometries are copies, which must be destroyed.
+func (layer Layer) ReadAll(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("Error: reading into %T, which is not a non-nil slice pointer", v)
+	}
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	if _, err := structInfo(elemType); err != nil {
+		return err
+	}
+
+	slice.SetLen(0)
+	layer.ResetReading()
+	for {
+		feature, err := layer.nextFeature()
+		if err != nil {
+			return err
+		}
+		if feature.cval == nil {
+			return nil
+		}
+		elem := reflect.New(elemType)
+		err = feature.Decode(elem.Interface())
+		feature.Destroy()
+		if err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, elem.Elem()))
+	}
+}
+
+// WriteAll creates one feature in the layer for each struct of the slice v, encoded as by Feature.Encode
+func (layer Layer) WriteAll(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("Error: writing %T, which is not a slice", v)
+	}
+	definition := layer.Definition()
+	for i := 0; i < rv.Len(); i++ {
+		feature := definition.Create()
+		err := feature.Encode(rv.Index(i).Interface())
+		if err == nil {
+			err = layer.Create(feature)
+		}
+		feature.Destroy()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateLayerFromStruct creates a layer in dataset with one field for each struct field of T that is not the
+// geometry or the identifier, typed as listed above. The layer has a geometry of unknown type in the spatial
+// reference srs if T has a geometry field, and none otherwise.
+func CreateLayerFromStruct[T any](dataset Dataset, name string, srs SpatialReference) (Layer, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	infos, err := structInfo(t)
+	if err != nil {
+		return Layer{nil}, err
+	}
+
+	geomType := GT_None
+	for _, info := range infos {
+		if info.role == structGeometry {
+			geomType = GT_Unknown
+		}
+	}
+	layer, err := dataset.CreateLayer(name, srs, geomType, nil)
+	if err != nil {
+		return layer, err
+	}
+
+	for _, info := range infos {
+		if info.role != structField {
+			continue
+		}
+		fd := CreateFieldDefinition(info.name, info.fieldType)
+		fd.SetSubType(info.subType)
+		err := layer.CreateField(fd, false)
+		fd.Destroy()
+		if err != nil {
+			return layer, fmt.Errorf("Error: field '%s' create error: %w", info.name, err)
+		}
+	}
+	return layer, nil
+}
@@ -0,0 +1,116 @@
+package gdal_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPlace struct {
+	ID         int64         `ogr:",fid"`
+	Name       string        `ogr:"name"`
+	Population int64         `ogr:"population"`
+	Area       float64       `ogr:"area"`
+	Capital    bool          `ogr:"capital"`
+	Founded    time.Time     `ogr:"founded"`
+	Mayor      *string       `ogr:"mayor"`
+	Tags       []string      `ogr:"tags"`
+	Codes      []int32       `ogr:"codes"`
+	Blob       []byte        `ogr:"blob"`
+	Location   gdal.Geometry `ogr:",geometry"`
+	Ignored    string        `ogr:"-"`
+}
+
+func TestStructMarshalling(t *testing.T) {
+	ds := testVectorDataset(t)
+	defer ds.Close()
+
+	layer, err := gdal.CreateLayerFromStruct[testPlace](ds, "places", gdal.SpatialReference{})
+	assert.NoError(t, err)
+	definition := layer.Definition()
+	assert.Equal(t, 9, definition.FieldCount())
+	assert.Equal(t, gdal.FT_StringList, definition.FieldDefinition(definition.FieldIndex("tags")).Type())
+
+	mayor := "Ann"
+	founded := time.Date(1850, 3, 4, 12, 30, 15, 0, time.UTC)
+	point, err := gdal.CreateFromWKT("POINT (1 2)", gdal.SpatialReference{})
+	assert.NoError(t, err)
+	defer point.Destroy()
+	places := []testPlace{
+		{
+			Name: "a", Population: 1 << 40, Area: 12.5, Capital: true, Founded: founded, Mayor: &mayor,
+			Tags: []string{"x", "y"}, Codes: []int32{1, 2, 3}, Blob: []byte{0, 1, 2}, Location: point,
+			Ignored: "ignored",
+		},
+		{Name: "b"},
+	}
+	assert.NoError(t, layer.WriteAll(places))
+
+	var read []testPlace
+	assert.NoError(t, layer.ReadAll(&read))
+	assert.Len(t, read, 2)
+	defer read[0].Location.Destroy()
+
+	a := read[0]
+	assert.Equal(t, "a", a.Name)
+	assert.Equal(t, int64(1<<40), a.Population)
+	assert.Equal(t, 12.5, a.Area)
+	assert.True(t, a.Capital)
+	assert.True(t, founded.Equal(a.Founded))
+	assert.Equal(t, "Ann", *a.Mayor)
+	assert.Equal(t, []string{"x", "y"}, a.Tags)
+	assert.Equal(t, []int32{1, 2, 3}, a.Codes)
+	assert.Equal(t, []byte{0, 1, 2}, a.Blob)
+	assert.Empty(t, a.Ignored)
+	wkt, err := a.Location.ToWKT()
+	assert.NoError(t, err)
+	assert.Equal(t, "POINT (1 2)", wkt)
+
+	b := read[1]
+	assert.Equal(t, "b", b.Name)
+	assert.Nil(t, b.Mayor)
+	assert.NotEqual(t, a.ID, b.ID)
+
+	feature := layer.Feature(b.ID)
+	defer feature.Destroy()
+	var single testPlace
+	assert.NoError(t, feature.Decode(&single))
+	assert.Equal(t, "b", single.Name)
+	assert.Error(t, feature.Decode(single))
+
+	type badPlace struct {
+		Center complex128
+	}
+	_, err = gdal.CreateLayerFromStruct[badPlace](ds, "bad", gdal.SpatialReference{})
+	assert.Error(t, err)
+}
+
+func TestStructMarshallingGPKG(t *testing.T) {
+	driver, err := gdal.GetDriverByName("GPKG")
+	assert.NoError(t, err)
+	ds := driver.Create("/vsimem/structs.gpkg", 0, 0, 0, gdal.Unknown, nil)
+	defer gdal.VSIUnlink("/vsimem/structs.gpkg")
+	defer ds.Close()
+
+	type counter struct {
+		ID    int64  `ogr:",fid"`
+		Name  string `ogr:"name"`
+		Count uint64 `ogr:"count"`
+	}
+	layer, err := gdal.CreateLayerFromStruct[counter](ds, "counters", gdal.SpatialReference{})
+	assert.NoError(t, err)
+
+	// zero identifiers are assigned by the layer, which rejects duplicate ones
+	assert.NoError(t, layer.WriteAll([]counter{{Name: "a", Count: 1}, {Name: "b", Count: 2}, {ID: 10, Name: "c"}}))
+	var read []counter
+	assert.NoError(t, layer.ReadAll(&read))
+	assert.Len(t, read, 3)
+	assert.NotEqual(t, read[0].ID, read[1].ID)
+	assert.Equal(t, int64(10), read[2].ID)
+	assert.Equal(t, uint64(2), read[1].Count)
+
+	assert.Error(t, layer.WriteAll([]counter{{Name: "d", Count: math.MaxUint64}}))
+}
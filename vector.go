@@ -0,0 +1,194 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Dataset vector functions                                        */
+/* -------------------------------------------------------------------- */
+
+// LayerCount fetches the number of vector layers in the dataset
+func (dataset Dataset) LayerCount() int {
+	return int(C.GDALDatasetGetLayerCount(dataset.cval))
+}
+
+// LayerByIndex fetches a layer of the dataset by index. The layer belongs to the dataset and must not be used
+// once the dataset is closed.
+func (dataset Dataset) LayerByIndex(index int) Layer {
+	return Layer{C.GDALDatasetGetLayer(dataset.cval, C.int(index))}
+}
+
+// Layers fetches all layers of the dataset, in order
+func (dataset Dataset) Layers() []Layer {
+	count := dataset.LayerCount()
+	layers := make([]Layer, count)
+	for i := 0; i < count; i++ {
+		layers[i] = dataset.LayerByIndex(i)
+	}
+	return layers
+}
+
+// LayerByName fetches a layer of the dataset by name
+func (dataset Dataset) LayerByName(name string) (Layer, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	layer := C.GDALDatasetGetLayerByName(dataset.cval, cName)
+	if layer == nil {
+		return Layer{nil}, fmt.Errorf("Error: layer '%s' not found", name)
+	}
+	return Layer{layer}, nil
+}
+
+// CreateLayer creates a new layer in a dataset opened for update. A zero SpatialReference creates a layer
+// without spatial reference.
+func (dataset Dataset) CreateLayer(
+	name string,
+	sr SpatialReference,
+	geomType GeometryType,
+	options []string,
+) (Layer, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	layer := C.GDALDatasetCreateLayer(
+		dataset.cval,
+		cName,
+		sr.cval,
+		C.OGRwkbGeometryType(geomType),
+		(**C.char)(unsafe.Pointer(&cOptions[0])),
+	)
+	if layer == nil {
+		return Layer{nil}, fmt.Errorf("Error: layer '%s' create error", name)
+	}
+	return Layer{layer}, nil
+}
+
+// CopyLayer duplicates source, which may belong to another dataset, as a new layer named name
+func (dataset Dataset) CopyLayer(source Layer, name string, options []string) (Layer, error) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	layer := C.GDALDatasetCopyLayer(
+		dataset.cval,
+		source.cval,
+		cName,
+		(**C.char)(unsafe.Pointer(&cOptions[0])),
+	)
+	if layer == nil {
+		return Layer{nil}, fmt.Errorf("Error: layer '%s' copy error", name)
+	}
+	return Layer{layer}, nil
+}
+
+// DeleteLayer deletes the layer at index from the dataset
+func (dataset Dataset) DeleteLayer(index int) error {
+	return OGRErr(C.GDALDatasetDeleteLayer(dataset.cval, C.int(index))).Err()
+}
+
+// TestCapability tests if the dataset has the indicated capability, e.g. "CreateLayer" or "Transactions"
+func (dataset Dataset) TestCapability(capability string) bool {
+	cCapability := C.CString(capability)
+	defer C.free(unsafe.Pointer(cCapability))
+	return C.GDALDatasetTestCapability(dataset.cval, cCapability) != 0
+}
+
+// ExecuteSQL runs an SQL statement against the dataset, with an optional spatial filter and dialect, e.g.
+// "SQLITE" or "OGRSQL"; an empty dialect selects the driver's default. Statements with a result set return a
+// layer that must be released with ReleaseResultSet; other statements return a nil layer.
+func (dataset Dataset) ExecuteSQL(sql string, filter Geometry, dialect string) (Layer, error) {
+	cSQL := C.CString(sql)
+	defer C.free(unsafe.Pointer(cSQL))
+	var cDialect *C.char
+	if dialect != "" {
+		cDialect = C.CString(dialect)
+		defer C.free(unsafe.Pointer(cDialect))
+	}
+
+	var layer C.OGRLayerH
+	cplErr := cplCall(func() {
+		layer = C.GDALDatasetExecuteSQL(dataset.cval, cSQL, filter.cval, cDialect)
+	})
+	if layer == nil && cplErr.failed() {
+		return Layer{nil}, fmt.Errorf("Error: SQL '%s' failed: %s", sql, cplErr.msg)
+	}
+	return Layer{layer}, nil
+}
+
+// ReleaseResultSet releases a layer returned by ExecuteSQL
+func (dataset Dataset) ReleaseResultSet(layer Layer) {
+	if dataset.cval == nil || layer.cval == nil {
+		return
+	}
+	C.GDALDatasetReleaseResultSet(dataset.cval, layer.cval)
+}
+
+// Transaction is a transaction started on a dataset with StartTransaction
+type Transaction struct {
+	dataset Dataset
+}
+
+// StartTransaction starts a transaction on the dataset. Drivers without native transactions, such as
+// Shapefile, only support emulated ones, which are used if force is set. It is typically used as:
+//
+//	tx, err := ds.StartTransaction(false)
+//	if err != nil { ... }
+//	defer tx.Rollback()
+//	... write features ...
+//	return tx.Commit()
+func (dataset Dataset) StartTransaction(force bool) (Transaction, error) {
+	err := OGRErr(C.GDALDatasetStartTransaction(dataset.cval, C.int(BoolToCInt(force)))).Err()
+	if err != nil {
+		return Transaction{Dataset{nil}}, err
+	}
+	return Transaction{dataset}, nil
+}
+
+// Commit commits the transaction. It does nothing if the transaction has already ended.
+func (tx *Transaction) Commit() error {
+	if tx.dataset.cval == nil {
+		return nil
+	}
+	err := OGRErr(C.GDALDatasetCommitTransaction(tx.dataset.cval)).Err()
+	tx.dataset.cval = nil
+	return err
+}
+
+// Rollback cancels the changes of the transaction. It does nothing if the transaction has already ended, so it
+// can be deferred right after StartTransaction.
+func (tx *Transaction) Rollback() error {
+	if tx.dataset.cval == nil {
+		return nil
+	}
+	err := OGRErr(C.GDALDatasetRollbackTransaction(tx.dataset.cval)).Err()
+	tx.dataset.cval = nil
+	return err
+}
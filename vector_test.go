@@ -0,0 +1,79 @@
+package gdal_test
+
+import (
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatasetLayers(t *testing.T) {
+	src, err := gdal.OpenBytes([]byte(testGeoJSON), gdal.GDALOFReadOnly|gdal.GDALOFVector, nil, nil)
+	assert.NoError(t, err)
+	defer src.Close()
+
+	assert.Equal(t, 1, src.LayerCount())
+	layers := src.Layers()
+	assert.Len(t, layers, 1)
+	_, err = src.LayerByName("missing")
+	assert.Error(t, err)
+
+	driver, err := gdal.GetDriverByName("GPKG")
+	assert.NoError(t, err)
+	ds := driver.Create("/vsimem/dataset_layers.gpkg", 0, 0, 0, gdal.Unknown, nil)
+	defer gdal.VSIUnlink("/vsimem/dataset_layers.gpkg")
+	defer ds.Close()
+	assert.True(t, ds.TestCapability("CreateLayer"))
+
+	copied, err := ds.CopyLayer(layers[0], "points", nil)
+	assert.NoError(t, err)
+	count, _ := copied.FeatureCount(true)
+	assert.Equal(t, 2, count)
+
+	empty, err := ds.CreateLayer("empty", gdal.SpatialReference{}, gdal.GT_Polygon, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "empty", empty.Name())
+	assert.Equal(t, 2, ds.LayerCount())
+
+	result, err := ds.ExecuteSQL("SELECT name FROM points WHERE value = 2", gdal.Geometry{}, "")
+	assert.NoError(t, err)
+	feature := result.NextFeature()
+	assert.Equal(t, "b", feature.FieldAsString(0))
+	feature.Destroy()
+	ds.ReleaseResultSet(result)
+
+	_, err = ds.ExecuteSQL("SELECT * FROM nowhere", gdal.Geometry{}, "")
+	assert.Error(t, err)
+
+	tx, err := ds.StartTransaction(false)
+	assert.NoError(t, err)
+	assert.NoError(t, copied.Delete(1))
+	assert.NoError(t, tx.Rollback())
+	assert.NoError(t, tx.Commit())
+	count, _ = copied.FeatureCount(true)
+	assert.Equal(t, 2, count)
+
+	tx, err = ds.StartTransaction(false)
+	assert.NoError(t, err)
+	assert.NoError(t, copied.Delete(1))
+	assert.NoError(t, tx.Commit())
+	count, _ = copied.FeatureCount(true)
+	assert.Equal(t, 1, count)
+
+	assert.NoError(t, ds.DeleteLayer(1))
+	_, err = ds.LayerByName("empty")
+	assert.Error(t, err)
+}
+
+func TestDataSourceShim(t *testing.T) {
+	writeVSIFile(t, "/vsimem/datasource.geojson", []byte(testGeoJSON))
+	defer gdal.VSIUnlink("/vsimem/datasource.geojson")
+
+	ds := gdal.OpenDataSource("/vsimem/datasource.geojson", 0)
+	defer ds.Destroy()
+	assert.Equal(t, "/vsimem/datasource.geojson", ds.Name())
+	assert.Equal(t, "GeoJSON", ds.Driver().Name())
+	assert.Equal(t, 1, ds.LayerCount())
+	count, _ := ds.LayerByIndex(0).FeatureCount(true)
+	assert.Equal(t, 2, count)
+}
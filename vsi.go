@@ -0,0 +1,476 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"runtime/cgo"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      VSIFile streaming                                               */
+/* -------------------------------------------------------------------- */
+
+var (
+	_ io.ReadWriteSeeker = VSIFile{}
+	_ io.ReaderAt        = VSIFile{}
+	_ io.Closer          = &VSIFile{}
+)
+
+// Read reads up to len(p) bytes from the current position. It returns io.EOF at the end of the file.
+func (file VSIFile) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	n := int(C.VSIFReadL(unsafe.Pointer(&p[0]), 1, C.size_t(len(p)), file.cval))
+	if n < len(p) {
+		if C.VSIFEofL(file.cval) != 0 {
+			return n, io.EOF
+		}
+		return n, fmt.Errorf("Error: VSIFile read failed after %d bytes", n)
+	}
+	return n, nil
+}
+
+// Write writes p at the current position
+func (file VSIFile) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	n := int(C.VSIFWriteL(unsafe.Pointer(&p[0]), 1, C.size_t(len(p)), file.cval))
+	if n < len(p) {
+		return n, fmt.Errorf("Error: VSIFile write failed after %d bytes: %w", n, io.ErrShortWrite)
+	}
+	return n, nil
+}
+
+// Seek sets the position of the next Read or Write, as io.Seeker
+func (file VSIFile) Seek(offset int64, whence int) (int64, error) {
+	var base int64
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		base = file.Tell()
+	case io.SeekEnd:
+		if C.VSIFSeekL(file.cval, 0, C.SEEK_END) != 0 {
+			return 0, errors.New("Error: VSIFile seek to end failed")
+		}
+		base = file.Tell()
+	default:
+		return 0, fmt.Errorf("Error: invalid whence %d", whence)
+	}
+	pos := base + offset
+	if pos < 0 {
+		return 0, fmt.Errorf("Error: negative position %d", pos)
+	}
+	if C.VSIFSeekL(file.cval, C.vsi_l_offset(pos), C.SEEK_SET) != 0 {
+		return 0, fmt.Errorf("Error: VSIFile seek to %d failed", pos)
+	}
+	return pos, nil
+}
+
+// ReadAt reads len(p) bytes at offset off without moving the current position. Concurrent calls on the same file
+// are not safe, as the underlying handle has a single position.
+func (file VSIFile) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("Error: negative offset %d", off)
+	}
+	pos := file.Tell()
+	defer C.VSIFSeekL(file.cval, C.vsi_l_offset(pos), C.SEEK_SET)
+
+	if C.VSIFSeekL(file.cval, C.vsi_l_offset(off), C.SEEK_SET) != 0 {
+		return 0, fmt.Errorf("Error: VSIFile seek to %d failed", off)
+	}
+	n, err := file.Read(p)
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Tell returns the current position in the file
+func (file VSIFile) Tell() int64 {
+	return int64(C.VSIFTellL(file.cval))
+}
+
+// Truncate extends or shrinks the file to size bytes
+func (file VSIFile) Truncate(size int64) error {
+	if size < 0 {
+		return fmt.Errorf("Error: negative size %d", size)
+	}
+	if C.VSIFTruncateL(file.cval, C.vsi_l_offset(size)) != 0 {
+		return fmt.Errorf("Error: VSIFile truncate to %d failed", size)
+	}
+	return nil
+}
+
+// Flush pending writes
+func (file VSIFile) Flush() error {
+	if C.VSIFFlushL(file.cval) != 0 {
+		return errors.New("Error: VSIFile flush failed")
+	}
+	return nil
+}
+
+// Close the file. Writes to remote file systems are committed at this point and may fail. Closing a closed file
+// does nothing.
+func (file *VSIFile) Close() error {
+	if file.cval == nil {
+		return nil
+	}
+	ret := C.VSIFCloseL(file.cval)
+	file.cval = nil
+	if ret != 0 {
+		return errors.New("Error: VSIFile close failed")
+	}
+	return nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      VSI file system operations                                      */
+/* -------------------------------------------------------------------- */
+
+const (
+	vsiModeTypeMask = 0170000
+	vsiModeDir      = 0040000
+	vsiModeSymlink  = 0120000
+)
+
+// vsiFileMode converts a POSIX st_mode to an os.FileMode
+func vsiFileMode(mode int) os.FileMode {
+	m := os.FileMode(mode & 0777)
+	switch mode & vsiModeTypeMask {
+	case vsiModeDir:
+		m |= os.ModeDir
+	case vsiModeSymlink:
+		m |= os.ModeSymlink
+	}
+	return m
+}
+
+// IsDir reports whether the stat describes a directory
+func (stat VSIStatGo) IsDir() bool {
+	return stat.Mode.IsDir()
+}
+
+// VSIStat fetches the size, mode and modification time of a file or directory. The error wraps fs.ErrNotExist
+// when the path does not exist.
+func VSIStat(path string) (VSIStatGo, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	var mode C.int
+	var size, mtime C.GIntBig
+	if C.goVSIStat(cPath, &mode, &size, &mtime) != 0 {
+		return VSIStatGo{}, fmt.Errorf("Error: stat '%s': %w", path, fs.ErrNotExist)
+	}
+	return VSIStatGo{
+		Exists:  true,
+		Size:    int64(size),
+		Mode:    vsiFileMode(int(mode)),
+		ModTime: time.Unix(int64(mtime), 0),
+	}, nil
+}
+
+// VSIReadDir lists the entries of a single directory, without "." and ".."
+func VSIReadDir(path string) []string {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	p := C.VSIReadDir(cPath)
+	defer C.CSLDestroy(p)
+
+	var names []string
+	for _, name := range cStringList(p) {
+		if name != "." && name != ".." {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// VSIDirEntry is an entry returned by VSIDir.Next. Size, Mode and ModTime are only meaningful when the matching
+// Known flag is set, as some file systems do not return them when listing.
+type VSIDirEntry struct {
+	Name         string
+	Size         int64
+	Mode         os.FileMode
+	ModTime      time.Time
+	SizeKnown    bool
+	ModeKnown    bool
+	ModTimeKnown bool
+}
+
+// VSIOpenDir opens a directory for streaming iteration. recurseDepth is 0 to list only the directory, -1 for
+// unlimited recursion. Names of entries in sub-directories are relative to path. The VSIDir must be closed.
+func VSIOpenDir(path string, recurseDepth int, options []string) (VSIDir, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	length := len(options)
+	cOptions := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		cOptions[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(cOptions[i]))
+	}
+	cOptions[length] = (*C.char)(unsafe.Pointer(nil))
+
+	dir := C.VSIOpenDir(cPath, C.int(recurseDepth), (**C.char)(unsafe.Pointer(&cOptions[0])))
+	if dir == nil {
+		return VSIDir{nil}, fmt.Errorf("Error: VSIDir '%s' open error", path)
+	}
+	return VSIDir{dir}, nil
+}
+
+// Next returns the next entry of the directory, and false once all entries have been read
+func (dir VSIDir) Next() (VSIDirEntry, bool) {
+	e := C.VSIGetNextDirEntry(dir.cval)
+	if e == nil {
+		return VSIDirEntry{}, false
+	}
+	entry := VSIDirEntry{
+		Name:         C.GoString(e.pszName),
+		SizeKnown:    e.bSizeKnown != 0,
+		ModeKnown:    e.bModeKnown != 0,
+		ModTimeKnown: e.bMTimeKnown != 0,
+	}
+	if entry.SizeKnown {
+		entry.Size = int64(e.nSize)
+	}
+	if entry.ModeKnown {
+		entry.Mode = vsiFileMode(int(e.nMode))
+	}
+	if entry.ModTimeKnown {
+		entry.ModTime = time.Unix(int64(e.nMTime), 0)
+	}
+	return entry, true
+}
+
+// Close the directory
+func (dir *VSIDir) Close() {
+	if dir.cval != nil {
+		C.VSICloseDir(dir.cval)
+		dir.cval = nil
+	}
+}
+
+// VSIMkdir creates a directory
+func VSIMkdir(path string, mode os.FileMode) error {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	if C.VSIMkdir(cPath, C.long(mode.Perm())) != 0 {
+		return fmt.Errorf("Error: mkdir '%s' failed", path)
+	}
+	return nil
+}
+
+// VSIMkdirRecursive creates a directory and its missing parents
+func VSIMkdirRecursive(path string, mode os.FileMode) error {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	if C.VSIMkdirRecursive(cPath, C.long(mode.Perm())) != 0 {
+		return fmt.Errorf("Error: mkdir '%s' failed", path)
+	}
+	return nil
+}
+
+// VSIRename renames a file or directory, within the same file system
+func VSIRename(oldPath, newPath string) error {
+	cOld := C.CString(oldPath)
+	defer C.free(unsafe.Pointer(cOld))
+	cNew := C.CString(newPath)
+	defer C.free(unsafe.Pointer(cNew))
+
+	if C.VSIRename(cOld, cNew) != 0 {
+		return fmt.Errorf("Error: rename '%s' to '%s' failed", oldPath, newPath)
+	}
+	return nil
+}
+
+// VSIRmdir deletes an empty directory
+func VSIRmdir(path string) error {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	if C.VSIRmdir(cPath) != 0 {
+		return fmt.Errorf("Error: rmdir '%s' failed", path)
+	}
+	return nil
+}
+
+// VSIRmdirRecursive deletes a directory and all its content
+func VSIRmdirRecursive(path string) error {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	if C.VSIRmdirRecursive(cPath) != 0 {
+		return fmt.Errorf("Error: recursive rmdir '%s' failed", path)
+	}
+	return nil
+}
+
+// VSICopyFile copies a file, possibly across file systems. progress may be nil. It fails on GDAL older than 3.7.
+func VSICopyFile(src, dst string, progress ProgressFunc, data interface{}) error {
+	cSrc := C.CString(src)
+	defer C.free(unsafe.Pointer(cSrc))
+	cDst := C.CString(dst)
+	defer C.free(unsafe.Pointer(cDst))
+
+	handle := cgo.NewHandle(&callbackProgress{fn: progress, data: data})
+	defer handle.Delete()
+
+	var ret C.int
+	cplErr := cplCall(func() {
+		ret = C.goVSICopyFile(cSrc, cDst, C.goGDALProgressHandleProxyB(), C.goGDALHandleArg(C.uintptr_t(handle)))
+	})
+	if ret != 0 {
+		return fmt.Errorf("Error: copy '%s' to '%s' failed: %s", src, dst, cplErr.msg)
+	}
+	return nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      In-memory datasets                                              */
+/* -------------------------------------------------------------------- */
+
+var memDatasets = struct {
+	sync.Mutex
+	count uint64
+	dirs  map[C.GDALDatasetH]string
+}{dirs: map[C.GDALDatasetH]string{}}
+
+// newMemDir returns a unique /vsimem directory name
+func newMemDir() string {
+	memDatasets.Lock()
+	defer memDatasets.Unlock()
+	memDatasets.count++
+	return fmt.Sprintf("/vsimem/godal_%d_%d", os.Getpid(), memDatasets.count)
+}
+
+// registerMemDataset records that dir backs the dataset, so that Dataset.Close deletes it
+func registerMemDataset(ds Dataset, dir string) {
+	memDatasets.Lock()
+	defer memDatasets.Unlock()
+	memDatasets.dirs[ds.cval] = dir
+}
+
+// takeMemDataset unregisters the /vsimem directory backing a dataset opened with OpenBytes, returning it. It must
+// be called before the dataset is closed, since GDAL may reuse the handle for a dataset opened concurrently.
+func takeMemDataset(h C.GDALDatasetH) (string, bool) {
+	memDatasets.Lock()
+	defer memDatasets.Unlock()
+	dir, ok := memDatasets.dirs[h]
+	delete(memDatasets.dirs, h)
+	return dir, ok
+}
+
+// NewMemPath returns the path of a file named filename in a new private /vsimem directory, where a dataset can be
+// written in memory. Pass the dataset to DeleteOnClose to remove the directory when the dataset is closed.
+func NewMemPath(filename string) string {
+	return newMemDir() + "/" + filename
+}
+
+// DeleteOnClose removes the private /vsimem directory of name, a path returned by NewMemPath, when the dataset is
+// closed
+func (dataset Dataset) DeleteOnClose(name string) error {
+	if dataset.cval == nil {
+		return fmt.Errorf("Error: dataset is not open")
+	}
+	dir := path.Dir(name)
+	if path.Dir(dir) != "/vsimem" || !strings.HasPrefix(path.Base(dir), "godal_") {
+		return fmt.Errorf("Error: '%s' was not returned by NewMemPath", name)
+	}
+	registerMemDataset(dataset, dir)
+	return nil
+}
+
+// OpenBytes opens a dataset from an encoded file held in memory. data is copied into a private /vsimem file that
+// is deleted, along with any side-car file GDAL writes next to it, when the dataset is closed. The file has no
+// extension, so formats that drivers identify by extension, such as CSV, must be opened with OpenBytesNamed.
+func OpenBytes(data []byte, openFlags OpenFlag, allowedDrivers []string, openOptions []string) (Dataset, error) {
+	return OpenBytesNamed(data, "data", openFlags, allowedDrivers, openOptions)
+}
+
+// OpenBytesNamed is OpenBytes with the file name given to the private /vsimem file, whose extension helps drivers
+// identify the format, e.g. "points.csv"
+func OpenBytesNamed(
+	data []byte,
+	filename string,
+	openFlags OpenFlag,
+	allowedDrivers []string,
+	openOptions []string,
+) (Dataset, error) {
+	if filename == "" || strings.ContainsAny(filename, "/\\") {
+		return Dataset{nil}, fmt.Errorf("Error: invalid file name '%s'", filename)
+	}
+	dir := newMemDir()
+	name := dir + "/" + filename
+
+	file, err := VSIFileFromMemBuffer(name, data, true)
+	if err != nil {
+		return Dataset{nil}, err
+	}
+	file.Close()
+
+	ds, err := OpenEx(name, openFlags, allowedDrivers, openOptions, nil)
+	if err != nil {
+		VSIRmdirRecursive(dir)
+		return Dataset{nil}, err
+	}
+	registerMemDataset(ds, dir)
+	return ds, nil
+}
+
+// VSIGetMemFileBuffer returns a copy of the content of a /vsimem file
+func VSIGetMemFileBuffer(path string) ([]byte, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	var length C.vsi_l_offset
+	p := C.VSIGetMemFileBuffer(cPath, &length, C.FALSE)
+	if p == nil {
+		return nil, fmt.Errorf("Error: '%s' is not a /vsimem file: %w", path, fs.ErrNotExist)
+	}
+	buf := make([]byte, int(length))
+	copy(buf, unsafe.Slice((*byte)(unsafe.Pointer(p)), int(length)))
+	return buf, nil
+}
+
+// CreateCopyToBytes encodes src with this driver and returns the resulting file. Drivers that write several files
+// only return the main one.
+func (driver Driver) CreateCopyToBytes(src Dataset, options []string) ([]byte, error) {
+	dir := newMemDir()
+	defer VSIRmdirRecursive(dir)
+
+	name := dir + "/data"
+	if ext := driver.MetadataItem(DMD_EXTENSION, ""); ext != "" {
+		name += "." + strings.Fields(ext)[0]
+	}
+
+	ds := driver.CreateCopy(name, src, 0, options, nil, nil)
+	if ds.cval == nil {
+		return nil, fmt.Errorf("Error: %s copy to bytes failed", driver.ShortName())
+	}
+	ds.Close()
+
+	return VSIGetMemFileBuffer(name)
+}
@@ -0,0 +1,259 @@
+package gdal_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVSIFileIO(t *testing.T) {
+	name := "/vsimem/test_vsifile.bin"
+	defer gdal.VSIUnlink(name)
+
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+
+	w, err := gdal.VSIFOpenL(name, "wb")
+	assert.NoError(t, err)
+	n, err := io.Copy(w, bytes.NewReader(content))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+	assert.NoError(t, w.Flush())
+	assert.NoError(t, w.Close())
+	// a second close, as a deferred one would do, is a no-op
+	assert.NoError(t, w.Close())
+
+	r, err := gdal.VSIFOpenL(name, "rb")
+	assert.NoError(t, err)
+	defer r.Close()
+
+	read, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, content, read)
+
+	pos, err := r.Seek(-5, io.SeekEnd)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)-5), pos)
+	buf := make([]byte, 10)
+	nr, err := r.Read(buf)
+	assert.Equal(t, 5, nr)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []byte("56789"), buf[:nr])
+
+	pos, err = r.Seek(3, io.SeekStart)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), pos)
+	pos, err = r.Seek(2, io.SeekCurrent)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), pos)
+	_, err = r.Seek(-10, io.SeekStart)
+	assert.Error(t, err)
+
+	nr, err = r.ReadAt(buf[:4], 21)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, nr)
+	assert.Equal(t, []byte("1234"), buf[:4])
+	assert.Equal(t, int64(5), r.Tell())
+
+	nr, err = r.ReadAt(buf, int64(len(content)-3))
+	assert.Equal(t, 3, nr)
+	assert.Equal(t, io.EOF, err)
+
+	assert.Equal(t, []byte("567"), gdal.VSIFReadL(1, 3, r))
+	assert.Len(t, gdal.VSIFReadL(1, 0, r), 0)
+	_, err = r.Seek(-2, io.SeekEnd)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("89"), gdal.VSIFReadL(1, 10, r))
+
+	rw, err := gdal.VSIFOpenL(name, "r+b")
+	assert.NoError(t, err)
+	assert.NoError(t, rw.Truncate(4))
+	assert.NoError(t, rw.Close())
+
+	r2, err := gdal.VSIFOpenL(name, "rb")
+	assert.NoError(t, err)
+	defer r2.Close()
+	read, err = io.ReadAll(r2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("0123"), read)
+}
+
+func writeVSIFile(t *testing.T, name string, content []byte) {
+	f, err := gdal.VSIFOpenL(name, "wb")
+	assert.NoError(t, err)
+	_, err = f.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestVSIFileSystem(t *testing.T) {
+	for _, root := range []string{"/vsimem/test_vsifs", t.TempDir() + "/test_vsifs"} {
+		t.Run(root, func(t *testing.T) {
+			assert.NoError(t, gdal.VSIMkdir(root, 0755))
+			defer gdal.VSIRmdirRecursive(root)
+
+			assert.NoError(t, gdal.VSIMkdirRecursive(root+"/a/b", 0755))
+			writeVSIFile(t, root+"/one.txt", []byte("hello"))
+			writeVSIFile(t, root+"/a/b/two.txt", []byte("world!"))
+
+			stat, err := gdal.VSIStat(root + "/one.txt")
+			assert.NoError(t, err)
+			assert.True(t, stat.Exists)
+			assert.False(t, stat.IsDir())
+			assert.Equal(t, int64(5), stat.Size)
+			assert.False(t, stat.ModTime.IsZero())
+
+			stat, err = gdal.VSIStat(root + "/a")
+			assert.NoError(t, err)
+			assert.True(t, stat.IsDir())
+
+			stat, err = gdal.VSIStat(root + "/missing")
+			assert.ErrorIs(t, err, fs.ErrNotExist)
+			assert.False(t, stat.Exists)
+
+			assert.ElementsMatch(t, []string{"a", "one.txt"}, gdal.VSIReadDir(root))
+
+			dir, err := gdal.VSIOpenDir(root, -1, nil)
+			assert.NoError(t, err)
+			entries := map[string]gdal.VSIDirEntry{}
+			for {
+				entry, ok := dir.Next()
+				if !ok {
+					break
+				}
+				entries[entry.Name] = entry
+			}
+			dir.Close()
+			assert.Len(t, entries, 4)
+			assert.True(t, entries["a/b"].Mode.IsDir())
+			assert.Equal(t, int64(6), entries["a/b/two.txt"].Size)
+
+			_, err = gdal.VSIOpenDir(root+"/missing", 0, nil)
+			assert.Error(t, err)
+
+			assert.NoError(t, gdal.VSIRename(root+"/one.txt", root+"/a/one.txt"))
+			_, err = gdal.VSIStat(root + "/one.txt")
+			assert.Error(t, err)
+
+			if gdal.VERSION_NUM >= 3070000 {
+				calls := 0
+				assert.NoError(t, gdal.VSICopyFile(root+"/a/one.txt", root+"/copy.txt", func(complete float64, message string, data interface{}) int {
+					calls++
+					return 1
+				}, nil))
+				assert.Greater(t, calls, 0)
+				stat, err = gdal.VSIStat(root + "/copy.txt")
+				assert.NoError(t, err)
+				assert.Equal(t, int64(5), stat.Size)
+			}
+			assert.Error(t, gdal.VSICopyFile(root+"/missing", root+"/copy2.txt", nil, nil))
+
+			assert.Error(t, gdal.VSIRmdir(root+"/a"))
+			assert.NoError(t, gdal.VSIMkdir(root+"/empty", 0755))
+			assert.NoError(t, gdal.VSIRmdir(root+"/empty"))
+
+			assert.NoError(t, gdal.VSIRmdirRecursive(root+"/a"))
+			_, err = gdal.VSIStat(root + "/a/b/two.txt")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func countMemDirs() int {
+	n := 0
+	for _, name := range gdal.VSIReadDir("/vsimem/") {
+		if strings.HasPrefix(name, "godal_") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestOpenBytes(t *testing.T) {
+	ds := testDataset(t)
+	defer ds.Close()
+	assert.NoError(t, ds.RasterBand(2).Fill(7, 0))
+
+	before := countMemDirs()
+
+	driver, err := gdal.GetDriverByName("GTiff")
+	assert.NoError(t, err)
+	data, err := driver.CreateCopyToBytes(ds, []string{"COMPRESS=DEFLATE"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("II*\x00"), data[:4])
+	assert.Equal(t, before, countMemDirs())
+
+	mem, err := gdal.OpenBytes(data, gdal.GDALOFReadOnly|gdal.GDALOFRaster, []string{"GTiff"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, before+1, countMemDirs())
+	assert.Equal(t, ds.RasterXSize(), mem.RasterXSize())
+	assert.Equal(t, ds.GeoTransform(), mem.GeoTransform())
+	read := make([]float32, 4)
+	assert.NoError(t, mem.RasterBand(2).IO(gdal.Read, 0, 0, 2, 2, read, 2, 2, 0, 0))
+	assert.Equal(t, []float32{7, 7, 7, 7}, read)
+	mem.Close()
+	assert.Equal(t, before, countMemDirs())
+
+	_, err = gdal.OpenBytes([]byte("not a raster"), gdal.GDALOFReadOnly|gdal.GDALOFRaster, nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, before, countMemDirs())
+
+	name := "/vsimem/test_membuffer.bin"
+	f, err := gdal.VSIFileFromMemBuffer(name, []byte("payload"), false)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	buf, err := gdal.VSIGetMemFileBuffer(name)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("payload"), buf)
+	gdal.VSIUnlink(name)
+	_, err = gdal.VSIGetMemFileBuffer(name)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestOpenBytesNamed(t *testing.T) {
+	driver, err := gdal.GetDriverByName("CSV")
+	assert.NoError(t, err)
+	name := "/vsimem/test_openbytes.csv"
+	defer gdal.VSIUnlink(name)
+	ds := driver.Create(name, 0, 0, 0, gdal.Unknown, nil)
+	layer, err := ds.CreateLayer("test_openbytes", gdal.SpatialReference{}, gdal.GT_None, nil)
+	assert.NoError(t, err)
+	fd := gdal.CreateFieldDefinition("name", gdal.FT_String)
+	assert.NoError(t, layer.CreateField(fd, false))
+	fd.Destroy()
+	for _, value := range []string{"a", "b"} {
+		feature := layer.Definition().Create()
+		feature.SetFieldString(0, value)
+		assert.NoError(t, layer.Create(feature))
+		feature.Destroy()
+	}
+	ds.Close()
+
+	data, err := gdal.VSIGetMemFileBuffer(name)
+	assert.NoError(t, err)
+	before := countMemDirs()
+
+	// the CSV driver only identifies files by their extension
+	_, err = gdal.OpenBytes(data, gdal.GDALOFReadOnly|gdal.GDALOFVector, nil, nil)
+	assert.Error(t, err)
+
+	mem, err := gdal.OpenBytesNamed(data, "points.csv", gdal.GDALOFReadOnly|gdal.GDALOFVector, []string{"CSV"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, before+1, countMemDirs())
+	var names []string
+	for feature, err := range mem.LayerByIndex(0).Features(context.Background()) {
+		assert.NoError(t, err)
+		names = append(names, feature.FieldAsString(0))
+	}
+	assert.Equal(t, []string{"a", "b"}, names)
+	mem.Close()
+	assert.Equal(t, before, countMemDirs())
+
+	_, err = gdal.OpenBytesNamed(data, "../points.csv", gdal.GDALOFReadOnly|gdal.GDALOFVector, nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package gdal
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+/* -------------------------------------------------------------------- */
+/*      io/fs adapter                                                   */
+/* -------------------------------------------------------------------- */
+
+type vsiFS struct {
+	prefix string
+}
+
+var (
+	_ fs.ReadDirFS  = vsiFS{}
+	_ fs.StatFS     = vsiFS{}
+	_ fs.ReadFileFS = vsiFS{}
+)
+
+// VSIFS returns a file system rooted at a VSI prefix, such as "/vsimem/dir", "/vsizip/archive.zip" or
+// "/vsis3/bucket/key". The returned fs.FS also implements fs.ReadDirFS, fs.StatFS and fs.ReadFileFS, and its
+// files implement io.Seeker and io.ReaderAt.
+func VSIFS(prefix string) fs.FS {
+	return vsiFS{prefix}
+}
+
+// path converts a fs.FS name to a VSI path
+func (fsys vsiFS) path(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return fsys.prefix, nil
+	}
+	return strings.TrimSuffix(fsys.prefix, "/") + "/" + name, nil
+}
+
+// Open opens the named file or directory
+func (fsys vsiFS) Open(name string) (fs.File, error) {
+	info, err := fsys.stat("open", name)
+	if err != nil {
+		return nil, err
+	}
+	full, _ := fsys.path("open", name)
+	if info.IsDir() {
+		return &vsiFSDir{fsys: fsys, name: name, info: info}, nil
+	}
+	file, err := VSIFOpenL(full, "rb")
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &vsiFSFile{file: file, name: name, info: info}, nil
+}
+
+// Stat returns the fs.FileInfo of the named file or directory
+func (fsys vsiFS) Stat(name string) (fs.FileInfo, error) {
+	return fsys.stat("stat", name)
+}
+
+func (fsys vsiFS) stat(op, name string) (vsiFileInfo, error) {
+	full, err := fsys.path(op, name)
+	if err != nil {
+		return vsiFileInfo{}, err
+	}
+	stat, err := VSIStat(full)
+	if err != nil {
+		return vsiFileInfo{}, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return vsiFileInfo{name: path.Base(name), stat: stat}, nil
+}
+
+// ReadDir lists the named directory, sorted by file name
+func (fsys vsiFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	full, err := fsys.path("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := VSIOpenDir(full, 0, nil)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	defer dir.Close()
+
+	var entries []fs.DirEntry
+	for {
+		e, ok := dir.Next()
+		if !ok {
+			break
+		}
+		if e.Name == "." || e.Name == ".." {
+			continue
+		}
+		entry := &vsiDirEntry{fsys: fsys, name: path.Join(name, e.Name)}
+		if e.ModeKnown {
+			entry.typ = e.Mode.Type()
+		} else {
+			info, err := fsys.stat("readdir", entry.name)
+			if err != nil {
+				return nil, err
+			}
+			entry.typ = info.Mode().Type()
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// ReadFile reads the whole named file
+func (fsys vsiFS) ReadFile(name string) ([]byte, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if _, ok := f.(*vsiFSDir); ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	return io.ReadAll(f)
+}
+
+type vsiFileInfo struct {
+	name string
+	stat VSIStatGo
+}
+
+func (info vsiFileInfo) Name() string       { return info.name }
+func (info vsiFileInfo) Size() int64        { return info.stat.Size }
+func (info vsiFileInfo) Mode() fs.FileMode  { return info.stat.Mode }
+func (info vsiFileInfo) ModTime() time.Time { return info.stat.ModTime }
+func (info vsiFileInfo) IsDir() bool        { return info.stat.IsDir() }
+func (info vsiFileInfo) Sys() interface{}   { return info.stat }
+
+type vsiDirEntry struct {
+	fsys vsiFS
+	name string
+	typ  fs.FileMode
+}
+
+func (e *vsiDirEntry) Name() string      { return path.Base(e.name) }
+func (e *vsiDirEntry) IsDir() bool       { return e.typ.IsDir() }
+func (e *vsiDirEntry) Type() fs.FileMode { return e.typ }
+
+func (e *vsiDirEntry) Info() (fs.FileInfo, error) {
+	return e.fsys.stat("stat", e.name)
+}
+
+// vsiFSFile is a regular file opened from a vsiFS
+type vsiFSFile struct {
+	file   VSIFile
+	name   string
+	info   vsiFileInfo
+	closed bool
+}
+
+func (f *vsiFSFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *vsiFSFile) Read(p []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	}
+	return f.file.Read(p)
+}
+
+func (f *vsiFSFile) ReadAt(p []byte, off int64) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	}
+	return f.file.ReadAt(p, off)
+}
+
+func (f *vsiFSFile) Seek(offset int64, whence int) (int64, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrClosed}
+	}
+	return f.file.Seek(offset, whence)
+}
+
+func (f *vsiFSFile) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	return f.file.Close()
+}
+
+// vsiFSDir is a directory opened from a vsiFS. Its entries are listed on the first ReadDir call.
+type vsiFSDir struct {
+	fsys    vsiFS
+	name    string
+	info    vsiFileInfo
+	entries []fs.DirEntry
+	listed  bool
+	closed  bool
+}
+
+func (d *vsiFSDir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *vsiFSDir) Read(p []byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+func (d *vsiFSDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.closed {
+		return nil, &fs.PathError{Op: "readdir", Path: d.name, Err: fs.ErrClosed}
+	}
+	if !d.listed {
+		entries, err := d.fsys.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries = entries
+		d.listed = true
+	}
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+func (d *vsiFSDir) Close() error {
+	if d.closed {
+		return &fs.PathError{Op: "close", Path: d.name, Err: fs.ErrClosed}
+	}
+	d.closed = true
+	return nil
+}
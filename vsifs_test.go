@@ -0,0 +1,62 @@
+package gdal_test
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVSIFS(t *testing.T) {
+	root := "/vsimem/test_vsifs_adapter"
+	assert.NoError(t, gdal.VSIMkdirRecursive(root+"/sub/deeper", 0755))
+	defer gdal.VSIRmdirRecursive(root)
+	writeVSIFile(t, root+"/a.txt", []byte("alpha"))
+	writeVSIFile(t, root+"/sub/b.txt", []byte("bravo"))
+	writeVSIFile(t, root+"/sub/deeper/c.txt", []byte("charlie"))
+
+	fsys := gdal.VSIFS(root)
+	assert.NoError(t, fstest.TestFS(fsys, "a.txt", "sub/b.txt", "sub/deeper/c.txt"))
+
+	var walked []string
+	assert.NoError(t, fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		walked = append(walked, p)
+		return nil
+	}))
+	assert.Equal(t, []string{".", "a.txt", "sub", "sub/b.txt", "sub/deeper", "sub/deeper/c.txt"}, walked)
+
+	data, err := fs.ReadFile(fsys, "sub/deeper/c.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "charlie", string(data))
+
+	_, err = fs.Stat(fsys, "missing.txt")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = fsys.Open("../escape")
+	assert.ErrorIs(t, err, fs.ErrInvalid)
+}
+
+func TestVSIFSZip(t *testing.T) {
+	name := "/vsimem/test_vsifs_adapter.zip"
+	defer gdal.VSIUnlink(name)
+	zw, err := gdal.VSICreateZip(name, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, zw.AddFile("a.txt", strings.NewReader("alpha"), nil))
+	assert.NoError(t, zw.AddFile("sub/b.txt", strings.NewReader("bravo"), nil))
+	assert.NoError(t, zw.Close())
+
+	fsys := gdal.VSIFS("/vsizip/" + name)
+	assert.NoError(t, fstest.TestFS(fsys, "a.txt", "sub/b.txt"))
+
+	data, err := fs.ReadFile(fsys, "sub/b.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "bravo", string(data))
+
+	_, err = fs.Stat(fsys, "sub/missing.txt")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
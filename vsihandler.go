@@ -0,0 +1,235 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/cgo"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Go backed VSI file systems                                      */
+/* -------------------------------------------------------------------- */
+
+// VSIOpener opens the file at path, relative to the handler prefix, and returns a reader over it along with its
+// size. If the reader also implements io.Closer it is closed when GDAL closes the file.
+type VSIOpener func(path string) (io.ReaderAt, int64, error)
+
+type vsiHandler struct {
+	opener VSIOpener
+}
+
+type vsiHandlerFile struct {
+	r    io.ReaderAt
+	size int64
+	pos  int64
+	eof  bool
+}
+
+var vsiHandlers = struct {
+	sync.Mutex
+	handles map[string]cgo.Handle
+}{handles: map[string]cgo.Handle{}}
+
+// RegisterVSIHandler installs a read-only VSI file system under prefix, e.g. "/vsigo_myfs/", so that any path
+// below it can be opened by GDAL. Every open and stat of a path calls opener. Multi-range reads, as issued for
+// COG tiles, are served with parallel ReadAt calls. Registering a prefix again replaces its opener.
+func RegisterVSIHandler(prefix string, opener VSIOpener) error {
+	if !strings.HasPrefix(prefix, "/vsi") || !strings.HasSuffix(prefix, "/") {
+		return fmt.Errorf("Error: VSI handler prefix '%s' must start with /vsi and end with /", prefix)
+	}
+	if opener == nil {
+		return fmt.Errorf("Error: VSI handler '%s' has no opener", prefix)
+	}
+	cPrefix := C.CString(prefix)
+	defer C.free(unsafe.Pointer(cPrefix))
+
+	vsiHandlers.Lock()
+	defer vsiHandlers.Unlock()
+
+	handle := cgo.NewHandle(&vsiHandler{opener})
+	if C.goVSIInstallPluginHandler(cPrefix, C.uintptr_t(handle)) != 0 {
+		handle.Delete()
+		return fmt.Errorf("Error: VSI handler '%s' install failed", prefix)
+	}
+	if old, ok := vsiHandlers.handles[prefix]; ok {
+		old.Delete()
+	}
+	vsiHandlers.handles[prefix] = handle
+	return nil
+}
+
+// open calls the opener with filename, which GDAL passes to plugin callbacks without the handler prefix
+func (h *vsiHandler) open(filename string) (io.ReaderAt, int64, error) {
+	return h.opener(filename)
+}
+
+func closeReaderAt(r io.ReaderAt) {
+	if c, ok := r.(io.Closer); ok {
+		c.Close()
+	}
+}
+
+func vsiHandlerFileFromHandle(file C.uintptr_t) *vsiHandlerFile {
+	return cgo.Handle(file).Value().(*vsiHandlerFile)
+}
+
+//export goVSIPluginStatA
+func goVSIPluginStatA(userData C.uintptr_t, filename *C.char, size *C.GIntBig) C.int {
+	h := cgo.Handle(userData).Value().(*vsiHandler)
+	r, n, err := h.open(C.GoString(filename))
+	if err != nil {
+		return -1
+	}
+	closeReaderAt(r)
+	*size = C.GIntBig(n)
+	return 0
+}
+
+//export goVSIPluginOpenA
+func goVSIPluginOpenA(userData C.uintptr_t, filename *C.char, access *C.char) C.uintptr_t {
+	if strings.ContainsAny(C.GoString(access), "wa+") {
+		return 0
+	}
+	h := cgo.Handle(userData).Value().(*vsiHandler)
+	r, n, err := h.open(C.GoString(filename))
+	if err != nil {
+		return 0
+	}
+	return C.uintptr_t(cgo.NewHandle(&vsiHandlerFile{r: r, size: n}))
+}
+
+//export goVSIPluginTellA
+func goVSIPluginTellA(file C.uintptr_t) C.GIntBig {
+	return C.GIntBig(vsiHandlerFileFromHandle(file).pos)
+}
+
+//export goVSIPluginSeekA
+func goVSIPluginSeekA(file C.uintptr_t, offset C.GIntBig, whence C.int) C.int {
+	f := vsiHandlerFileFromHandle(file)
+	switch whence {
+	case C.SEEK_SET:
+		f.pos = int64(offset)
+	case C.SEEK_CUR:
+		f.pos += int64(offset)
+	case C.SEEK_END:
+		f.pos = f.size + int64(offset)
+	default:
+		return -1
+	}
+	f.eof = false
+	return 0
+}
+
+//export goVSIPluginReadA
+func goVSIPluginReadA(file C.uintptr_t, buffer unsafe.Pointer, size C.size_t) C.size_t {
+	f := vsiHandlerFileFromHandle(file)
+	want := int64(size)
+	if f.pos+want > f.size {
+		want = f.size - f.pos
+		f.eof = true
+	}
+	if want <= 0 {
+		return 0
+	}
+	n, err := f.r.ReadAt(unsafe.Slice((*byte)(buffer), want), f.pos)
+	if err != nil && err != io.EOF {
+		f.eof = true
+	}
+	f.pos += int64(n)
+	return C.size_t(n)
+}
+
+//export goVSIPluginReadMultiRangeA
+func goVSIPluginReadMultiRangeA(file C.uintptr_t, ranges C.int, data *unsafe.Pointer, offsets *C.vsi_l_offset, sizes *C.size_t) C.int {
+	f := vsiHandlerFileFromHandle(file)
+	buffers := unsafe.Slice(data, int(ranges))
+	offs := unsafe.Slice(offsets, int(ranges))
+	lens := unsafe.Slice(sizes, int(ranges))
+
+	errs := make([]error, len(buffers))
+	var wg sync.WaitGroup
+	for i := range buffers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			buf := unsafe.Slice((*byte)(buffers[i]), int(lens[i]))
+			n, err := f.r.ReadAt(buf, int64(offs[i]))
+			if n == len(buf) {
+				err = nil
+			} else if err == nil {
+				err = io.ErrUnexpectedEOF
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return -1
+		}
+	}
+	return 0
+}
+
+//export goVSIPluginEofA
+func goVSIPluginEofA(file C.uintptr_t) C.int {
+	return C.int(BoolToCInt(vsiHandlerFileFromHandle(file).eof))
+}
+
+//export goVSIPluginCloseA
+func goVSIPluginCloseA(file C.uintptr_t) C.int {
+	h := cgo.Handle(file)
+	closeReaderAt(h.Value().(*vsiHandlerFile).r)
+	h.Delete()
+	return 0
+}
+
+// ReadMultiRange reads several ranges of the file at once, filling each buffer from the matching offset. Network
+// file systems may merge or parallelize the requests.
+func (file VSIFile) ReadMultiRange(offsets []int64, buffers [][]byte) error {
+	if len(offsets) != len(buffers) {
+		return fmt.Errorf("Error: %d offsets for %d buffers", len(offsets), len(buffers))
+	}
+	if len(buffers) == 0 {
+		return nil
+	}
+
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+
+	n := len(buffers)
+	cData := unsafe.Slice((*unsafe.Pointer)(C.malloc(C.size_t(n)*C.size_t(unsafe.Sizeof(uintptr(0))))), n)
+	defer C.free(unsafe.Pointer(&cData[0]))
+	cOffsets := make([]C.vsi_l_offset, n)
+	cSizes := make([]C.size_t, n)
+	for i, buf := range buffers {
+		if len(buf) == 0 {
+			cData[i] = nil
+		} else {
+			pinner.Pin(&buf[0])
+			cData[i] = unsafe.Pointer(&buf[0])
+		}
+		cOffsets[i] = C.vsi_l_offset(offsets[i])
+		cSizes[i] = C.size_t(len(buf))
+	}
+
+	if C.VSIFReadMultiRangeL(C.int(n), &cData[0], &cOffsets[0], &cSizes[0], file.cval) != 0 {
+		return fmt.Errorf("Error: VSIFile multi-range read failed")
+	}
+	return nil
+}
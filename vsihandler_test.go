@@ -0,0 +1,91 @@
+package gdal_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingReader struct {
+	*bytes.Reader
+	mu    *sync.Mutex
+	reads *int
+}
+
+func (r countingReader) ReadAt(p []byte, off int64) (int, error) {
+	r.mu.Lock()
+	*r.reads++
+	r.mu.Unlock()
+	return r.Reader.ReadAt(p, off)
+}
+
+func TestRegisterVSIHandler(t *testing.T) {
+	ds := testDataset(t)
+	defer ds.Close()
+	band := ds.RasterBand(1)
+	values := make([]float32, ds.RasterXSize()*ds.RasterYSize())
+	for i := range values {
+		values[i] = float32(i)
+	}
+	assert.NoError(t, band.IO(gdal.Write, 0, 0, ds.RasterXSize(), ds.RasterYSize(), values, ds.RasterXSize(), ds.RasterYSize(), 0, 0))
+
+	driver, err := gdal.GetDriverByName("GTiff")
+	assert.NoError(t, err)
+	tmp := "/vsimem/test_vsihandler.tif"
+	out := driver.CreateCopy(tmp, ds, 0, []string{"TILED=YES", "BLOCKXSIZE=16", "BLOCKYSIZE=16"}, nil, nil)
+	out.Close()
+	f, err := gdal.VSIFOpenL(tmp, "rb")
+	assert.NoError(t, err)
+	content, err := io.ReadAll(f)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	gdal.VSIUnlink(tmp)
+
+	store := map[string][]byte{"images/tiled.tif": content}
+	var mu sync.Mutex
+	reads := 0
+	assert.NoError(t, gdal.RegisterVSIHandler("/vsigo_test/", func(path string) (io.ReaderAt, int64, error) {
+		data, ok := store[path]
+		if !ok {
+			return nil, 0, fmt.Errorf("%s: not found", path)
+		}
+		return countingReader{bytes.NewReader(data), &mu, &reads}, int64(len(data)), nil
+	}))
+	assert.Error(t, gdal.RegisterVSIHandler("/notvsi/", nil))
+
+	stat, err := gdal.VSIStat("/vsigo_test/images/tiled.tif")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), stat.Size)
+	_, err = gdal.VSIStat("/vsigo_test/images/missing.tif")
+	assert.Error(t, err)
+
+	remote, err := gdal.Open("/vsigo_test/images/tiled.tif", gdal.ReadOnly)
+	assert.NoError(t, err)
+	defer remote.Close()
+	read := make([]float32, len(values))
+	assert.NoError(t, remote.RasterBand(1).IO(gdal.Read, 0, 0, remote.RasterXSize(), remote.RasterYSize(), read, remote.RasterXSize(), remote.RasterYSize(), 0, 0))
+	assert.Equal(t, values, read)
+	assert.Greater(t, reads, 0)
+
+	_, err = gdal.Open("/vsigo_test/images/missing.tif", gdal.ReadOnly)
+	assert.Error(t, err)
+
+	vf, err := gdal.VSIFOpenL("/vsigo_test/images/tiled.tif", "rb")
+	assert.NoError(t, err)
+	defer vf.Close()
+	buffers := [][]byte{make([]byte, 4), make([]byte, 8), make([]byte, 2)}
+	assert.NoError(t, vf.ReadMultiRange([]int64{0, 100, int64(len(content) - 2)}, buffers))
+	assert.Equal(t, content[:4], buffers[0])
+	assert.Equal(t, content[100:108], buffers[1])
+	assert.Equal(t, content[len(content)-2:], buffers[2])
+	assert.Error(t, vf.ReadMultiRange([]int64{int64(len(content))}, [][]byte{make([]byte, 4)}))
+	assert.Error(t, vf.ReadMultiRange([]int64{0}, nil))
+
+	_, err = gdal.VSIFOpenL("/vsigo_test/images/tiled.tif", "wb")
+	assert.Error(t, err)
+}
@@ -0,0 +1,495 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "gdal_version.h"
+
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#cgo windows LDFLAGS: -Lc:/gdal/release-1600-x64/lib -lgdal_i
+#cgo windows CFLAGS: -IC:/gdal/release-1600-x64/include
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/cgo"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+/* --------------------------------------------- */
+/* Transformers                                  */
+/* --------------------------------------------- */
+
+// Transformer maps points between destination and source pixel/line space. Points are transformed in place and ok
+// receives the per-point success. GDAL cannot clone a Go transformer for its worker threads, so warping with one is
+// single-threaded: CreateWarpOperation rejects it when NUM_THREADS or GDAL_NUM_THREADS asks for more threads.
+type Transformer interface {
+	Transform(dstToSrc bool, x, y, z []float64, ok []bool) error
+}
+
+// nativeTransformer is implemented by transformers backed by a GDAL C transformer, which the warper can call
+// directly without going through Go
+type nativeTransformer interface {
+	transformerFunc() (C.GDALTransformerFunc, unsafe.Pointer)
+}
+
+//export goGDALTransformerHandleProxyA
+func goGDALTransformerHandleProxyA(
+	handle C.uintptr_t,
+	dstToSrc C.int,
+	pointCount C.int,
+	x, y, z *C.double,
+	success *C.int,
+) C.int {
+	t := cgo.Handle(handle).Value().(Transformer)
+	n := int(pointCount)
+	if n == 0 {
+		return 1
+	}
+
+	xs := unsafe.Slice((*float64)(unsafe.Pointer(x)), n)
+	ys := unsafe.Slice((*float64)(unsafe.Pointer(y)), n)
+	var zs []float64
+	if z != nil {
+		zs = unsafe.Slice((*float64)(unsafe.Pointer(z)), n)
+	} else {
+		zs = make([]float64, n)
+	}
+
+	ok := make([]bool, n)
+	err := t.Transform(dstToSrc != 0, xs, ys, zs, ok)
+
+	results := unsafe.Slice(success, n)
+	for i := range ok {
+		results[i] = BoolToCInt(ok[i])
+	}
+	if err != nil {
+		return 0
+	}
+	return 1
+}
+
+//export goGDALProgressHandleProxyA
+func goGDALProgressHandleProxyA(complete C.double, message *C.char, handle C.uintptr_t) C.int {
+	p := cgo.Handle(handle).Value().(*callbackProgress)
+	return C.int(p.call(float64(complete), C.GoString(message)))
+}
+
+// GenImgProjTransformer transforms between the pixel/line spaces of two georeferenced images
+type GenImgProjTransformer struct {
+	// void*
+	cval unsafe.Pointer
+}
+
+// CreateGenImgProjTransformer2 creates a transformer from src pixel/line to dst pixel/line coordinates. If dst is
+// a null Dataset, the destination is georeferenced coordinates. Options are the GDALCreateGenImgProjTransformer2
+// options, e.g. SRC_SRS, DST_SRS, METHOD=RPC or RPC_DEM.
+func CreateGenImgProjTransformer2(src, dst Dataset, options []string) (GenImgProjTransformer, error) {
+	opts := make([]*C.char, len(options)+1)
+	for i, s := range options {
+		opts[i] = C.CString(s)
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[len(options)] = (*C.char)(unsafe.Pointer(nil))
+
+	h := C.GDALCreateGenImgProjTransformer2(src.cval, dst.cval, (**C.char)(unsafe.Pointer(&opts[0])))
+	if h == nil {
+		return GenImgProjTransformer{}, fmt.Errorf("GDALCreateGenImgProjTransformer2 failed")
+	}
+	return GenImgProjTransformer{h}, nil
+}
+
+// Destroy the transformer
+func (t *GenImgProjTransformer) Destroy() {
+	if t.cval != nil {
+		C.GDALDestroyGenImgProjTransformer(t.cval)
+		t.cval = nil
+	}
+}
+
+// Transform points in place, from destination to source pixel/line space if dstToSrc is true
+func (t GenImgProjTransformer) Transform(dstToSrc bool, x, y, z []float64, ok []bool) error {
+	if t.cval == nil {
+		return fmt.Errorf("GenImgProjTransformer is not initialized")
+	}
+	n := len(x)
+	if n != len(y) || n != len(z) || n != len(ok) {
+		return fmt.Errorf("x, y, z and ok slices must have the same length")
+	}
+	if n == 0 {
+		return nil
+	}
+
+	res := make([]C.int, n)
+	C.GDALGenImgProjTransform(
+		t.cval,
+		BoolToCInt(dstToSrc),
+		C.int(n),
+		(*C.double)(&x[0]),
+		(*C.double)(&y[0]),
+		(*C.double)(&z[0]),
+		&res[0],
+	)
+
+	var err error
+	for i, r := range res {
+		ok[i] = r != 0
+		if r == 0 {
+			err = errors.Join(err, fmt.Errorf("transform failed for point %d", i))
+		}
+	}
+	return err
+}
+
+func (t GenImgProjTransformer) transformerFunc() (C.GDALTransformerFunc, unsafe.Pointer) {
+	return C.GDALTransformerFunc(C.GDALGenImgProjTransform), t.cval
+}
+
+/* --------------------------------------------- */
+/* Warp options                                  */
+/* --------------------------------------------- */
+
+// WarpOptions wraps GDALWarpOptions, the configuration of a low-level warp operation
+type WarpOptions struct {
+	cval        *C.GDALWarpOptions
+	transformer Transformer
+}
+
+// CreateWarpOptions allocates warp options with GDAL defaults
+func CreateWarpOptions() *WarpOptions {
+	return &WarpOptions{cval: C.GDALCreateWarpOptions()}
+}
+
+// Destroy the warp options, including any cutline and band lists they own
+func (wo *WarpOptions) Destroy() {
+	if wo.cval != nil {
+		C.GDALDestroyWarpOptions(wo.cval)
+		wo.cval = nil
+	}
+	wo.transformer = nil
+}
+
+// SetSourceDataset sets the dataset to read from
+func (wo *WarpOptions) SetSourceDataset(ds Dataset) {
+	wo.cval.hSrcDS = ds.cval
+}
+
+// SetDestinationDataset sets the dataset to write into
+func (wo *WarpOptions) SetDestinationDataset(ds Dataset) {
+	wo.cval.hDstDS = ds.cval
+}
+
+// SetBands sets the 1-based source bands and the destination bands they are warped into
+func (wo *WarpOptions) SetBands(src, dst []int) error {
+	if len(src) != len(dst) {
+		return fmt.Errorf("source and destination band lists must have the same length")
+	}
+	wo.freeBandArrays()
+
+	n := len(src)
+	wo.cval.nBandCount = C.int(n)
+	if n == 0 {
+		return nil
+	}
+	wo.cval.panSrcBands = (*C.int)(C.CPLMalloc(C.size_t(n) * C.size_t(unsafe.Sizeof(C.int(0)))))
+	wo.cval.panDstBands = (*C.int)(C.CPLMalloc(C.size_t(n) * C.size_t(unsafe.Sizeof(C.int(0)))))
+	srcBands := unsafe.Slice(wo.cval.panSrcBands, n)
+	dstBands := unsafe.Slice(wo.cval.panDstBands, n)
+	for i := 0; i < n; i++ {
+		srcBands[i] = C.int(src[i])
+		dstBands[i] = C.int(dst[i])
+	}
+	return nil
+}
+
+// SetSourceNoData sets a per-band source nodata value. SetBands must be called first.
+func (wo *WarpOptions) SetSourceNoData(values []float64) error {
+	re, im, err := wo.noDataArrays(values)
+	if err != nil {
+		return err
+	}
+	C.VSIFree(unsafe.Pointer(wo.cval.padfSrcNoDataReal))
+	C.VSIFree(unsafe.Pointer(wo.cval.padfSrcNoDataImag))
+	wo.cval.padfSrcNoDataReal = re
+	wo.cval.padfSrcNoDataImag = im
+	return nil
+}
+
+// SetDestinationNoData sets a per-band destination nodata value. SetBands must be called first.
+func (wo *WarpOptions) SetDestinationNoData(values []float64) error {
+	re, im, err := wo.noDataArrays(values)
+	if err != nil {
+		return err
+	}
+	C.VSIFree(unsafe.Pointer(wo.cval.padfDstNoDataReal))
+	C.VSIFree(unsafe.Pointer(wo.cval.padfDstNoDataImag))
+	wo.cval.padfDstNoDataReal = re
+	wo.cval.padfDstNoDataImag = im
+	return nil
+}
+
+// SetResampleAlg sets the resampling algorithm
+func (wo *WarpOptions) SetResampleAlg(alg ResampleAlg) {
+	wo.cval.eResampleAlg = C.GDALResampleAlg(alg)
+}
+
+// SetWorkingDataType sets the data type used for the warp computation
+func (wo *WarpOptions) SetWorkingDataType(dataType DataType) {
+	wo.cval.eWorkingDataType = C.GDALDataType(dataType)
+}
+
+// SetMemoryLimit sets the amount of memory, in bytes, the warper may use for each chunk
+func (wo *WarpOptions) SetMemoryLimit(bytes float64) {
+	wo.cval.dfWarpMemoryLimit = C.double(bytes)
+}
+
+// SetNumThreads sets the NUM_THREADS warp option. A value <= 0 uses all CPUs. Only transformers backed by GDAL,
+// such as GenImgProjTransformer, can be used with more than one thread.
+func (wo *WarpOptions) SetNumThreads(threads int) {
+	if threads <= 0 {
+		wo.SetWarpOption("NUM_THREADS", "ALL_CPUS")
+		return
+	}
+	wo.SetWarpOption("NUM_THREADS", strconv.Itoa(threads))
+}
+
+// SetWarpOption sets one of the GDALWarpOptions::papszWarpOptions items, e.g. INIT_DEST or CUTLINE_ALL_TOUCHED
+func (wo *WarpOptions) SetWarpOption(key, value string) {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
+	wo.cval.papszWarpOptions = C.CSLSetNameValue(wo.cval.papszWarpOptions, cKey, cValue)
+}
+
+// WarpOption fetches one of the papszWarpOptions items
+func (wo *WarpOptions) WarpOption(key string) string {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	return C.GoString(C.CSLFetchNameValue(wo.cval.papszWarpOptions, cKey))
+}
+
+// SetCutline restricts the warp to a polygon expressed in source pixel/line coordinates. The geometry is copied.
+func (wo *WarpOptions) SetCutline(cutline Geometry) {
+	if wo.cval.hCutline != nil {
+		C.OGR_G_DestroyGeometry(C.OGRGeometryH(wo.cval.hCutline))
+		wo.cval.hCutline = nil
+	}
+	if cutline.cval != nil {
+		wo.cval.hCutline = unsafe.Pointer(C.OGR_G_Clone(cutline.cval))
+	}
+}
+
+// SetCutlineBlendDistance sets the distance, in pixels, over which to blend along the cutline
+func (wo *WarpOptions) SetCutlineBlendDistance(distance float64) {
+	wo.cval.dfCutlineBlendDist = C.double(distance)
+}
+
+// SetTransformer sets the transformer from destination to source pixel/line space
+func (wo *WarpOptions) SetTransformer(t Transformer) {
+	wo.transformer = t
+}
+
+// threads returns the number of threads the warper runs, from NUM_THREADS or else the GDAL_NUM_THREADS config option
+func (wo *WarpOptions) threads() int {
+	value := wo.WarpOption("NUM_THREADS")
+	if value == "" {
+		value = GetConfigOption("GDAL_NUM_THREADS", "1")
+	}
+	if strings.EqualFold(value, "ALL_CPUS") {
+		return runtime.NumCPU()
+	}
+	threads, err := strconv.Atoi(value)
+	if err != nil || threads < 1 {
+		return 1
+	}
+	return threads
+}
+
+func (wo *WarpOptions) freeBandArrays() {
+	C.VSIFree(unsafe.Pointer(wo.cval.panSrcBands))
+	C.VSIFree(unsafe.Pointer(wo.cval.panDstBands))
+	wo.cval.panSrcBands = nil
+	wo.cval.panDstBands = nil
+	for _, p := range []**C.double{
+		&wo.cval.padfSrcNoDataReal, &wo.cval.padfSrcNoDataImag,
+		&wo.cval.padfDstNoDataReal, &wo.cval.padfDstNoDataImag,
+	} {
+		C.VSIFree(unsafe.Pointer(*p))
+		*p = nil
+	}
+}
+
+func (wo *WarpOptions) noDataArrays(values []float64) (re, im *C.double, err error) {
+	n := int(wo.cval.nBandCount)
+	if n == 0 {
+		return nil, nil, fmt.Errorf("bands must be set before nodata values")
+	}
+	if len(values) != n {
+		return nil, nil, fmt.Errorf("expected %d nodata values, got %d", n, len(values))
+	}
+	re = (*C.double)(C.CPLMalloc(C.size_t(n) * C.size_t(unsafe.Sizeof(C.double(0)))))
+	im = (*C.double)(C.CPLCalloc(C.size_t(n), C.size_t(unsafe.Sizeof(C.double(0)))))
+	reals := unsafe.Slice(re, n)
+	for i, v := range values {
+		reals[i] = C.double(v)
+	}
+	return re, im, nil
+}
+
+/* --------------------------------------------- */
+/* Warp operation                                */
+/* --------------------------------------------- */
+
+type callbackProgress struct {
+	ctx  context.Context
+	fn   ProgressFunc
+	data interface{}
+}
+
+func (p *callbackProgress) call(complete float64, message string) int {
+	if p.ctx != nil && p.ctx.Err() != nil {
+		return 0
+	}
+	if p.fn == nil {
+		return 1
+	}
+	return p.fn(complete, message, p.data)
+}
+
+// WarpOperation wraps GDALWarpOperation, which warps regions of the source into the destination of its options.
+// Calls to ChunkAndWarpImage and WarpRegion on one operation run one at a time, as they share its progress callback.
+type WarpOperation struct {
+	cval     C.GDALWarpOperationH
+	mu       sync.Mutex
+	progress *callbackProgress
+	handles  []cgo.Handle
+}
+
+// CreateWarpOperation validates the options and prepares a warp operation. The options are copied, so they may be
+// destroyed or reused once the operation is created.
+func CreateWarpOperation(options *WarpOptions) (*WarpOperation, error) {
+	if options == nil || options.cval == nil {
+		return nil, fmt.Errorf("warp options are not initialized")
+	}
+	if options.transformer == nil {
+		return nil, fmt.Errorf("warp options have no transformer")
+	}
+	if _, ok := options.transformer.(nativeTransformer); !ok {
+		if threads := options.threads(); threads > 1 {
+			return nil, fmt.Errorf("a Go transformer cannot be used with %d warp threads", threads)
+		}
+	}
+
+	op := &WarpOperation{progress: &callbackProgress{}}
+
+	progressHandle := cgo.NewHandle(op.progress)
+	op.handles = append(op.handles, progressHandle)
+	C.goGDALWarpOptionsSetProgressHandle(options.cval, C.uintptr_t(progressHandle))
+
+	if native, ok := options.transformer.(nativeTransformer); ok {
+		fn, arg := native.transformerFunc()
+		options.cval.pfnTransformer = fn
+		options.cval.pTransformerArg = arg
+	} else {
+		transformerHandle := cgo.NewHandle(options.transformer)
+		op.handles = append(op.handles, transformerHandle)
+		C.goGDALWarpOptionsSetTransformerHandle(options.cval, C.uintptr_t(transformerHandle))
+	}
+
+	op.cval = C.GDALCreateWarpOperation(options.cval)
+
+	// the operation holds its own copy of the callbacks
+	options.cval.pfnProgress = nil
+	options.cval.pProgressArg = nil
+	options.cval.pfnTransformer = nil
+	options.cval.pTransformerArg = nil
+
+	if op.cval == nil {
+		op.Destroy()
+		return nil, fmt.Errorf("GDALCreateWarpOperation failed: invalid warp options")
+	}
+	return op, nil
+}
+
+// Destroy the warp operation
+func (op *WarpOperation) Destroy() {
+	if op.cval != nil {
+		C.GDALDestroyWarpOperation(op.cval)
+		op.cval = nil
+	}
+	for _, h := range op.handles {
+		h.Delete()
+	}
+	op.handles = nil
+}
+
+// ChunkAndWarpImage warps the destination window, splitting it into chunks that fit in the memory limit. The
+// operation stops when ctx is cancelled or progress returns 0.
+func (op *WarpOperation) ChunkAndWarpImage(
+	ctx context.Context,
+	dstXOff, dstYOff, dstXSize, dstYSize int,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	if op.cval == nil {
+		return fmt.Errorf("WarpOperation is not initialized")
+	}
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	op.setProgress(ctx, progress, data)
+	defer op.setProgress(nil, nil, nil)
+
+	err := CPLErr(
+		C.GDALChunkAndWarpImage(
+			op.cval,
+			C.int(dstXOff), C.int(dstYOff), C.int(dstXSize), C.int(dstYSize),
+		),
+	).Err()
+	if ctx != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// WarpRegion warps the source window into the destination window in a single chunk. The operation stops when ctx
+// is cancelled or progress returns 0.
+func (op *WarpOperation) WarpRegion(
+	ctx context.Context,
+	dstXOff, dstYOff, dstXSize, dstYSize int,
+	srcXOff, srcYOff, srcXSize, srcYSize int,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	if op.cval == nil {
+		return fmt.Errorf("WarpOperation is not initialized")
+	}
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	op.setProgress(ctx, progress, data)
+	defer op.setProgress(nil, nil, nil)
+
+	err := CPLErr(
+		C.GDALWarpRegion(
+			op.cval,
+			C.int(dstXOff), C.int(dstYOff), C.int(dstXSize), C.int(dstYSize),
+			C.int(srcXOff), C.int(srcYOff), C.int(srcXSize), C.int(srcYSize),
+		),
+	).Err()
+	if ctx != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+func (op *WarpOperation) setProgress(ctx context.Context, progress ProgressFunc, data interface{}) {
+	op.progress.ctx = ctx
+	op.progress.fn = progress
+	op.progress.data = data
+}
@@ -0,0 +1,189 @@
+package gdal_test
+
+import (
+	"context"
+	"testing"
+
+	gdal "github.com/seerai/godal"
+	"github.com/stretchr/testify/assert"
+)
+
+// shiftTransformer maps destination pixels to source pixels offset by (dx, dy)
+type shiftTransformer struct {
+	dx, dy float64
+}
+
+func (s shiftTransformer) Transform(dstToSrc bool, x, y, z []float64, ok []bool) error {
+	sign := 1.0
+	if !dstToSrc {
+		sign = -1.0
+	}
+	for i := range x {
+		x[i] += sign * s.dx
+		y[i] += sign * s.dy
+		ok[i] = true
+	}
+	return nil
+}
+
+func warpTestDatasets(t *testing.T) (gdal.Dataset, gdal.Dataset) {
+	driver, err := gdal.GetDriverByName("MEM")
+	assert.NoError(t, err)
+
+	src := driver.Create("", 64, 64, 1, gdal.Byte, nil)
+	src.SetGeoTransform([6]float64{0, 1, 0, 64, 0, -1})
+	src.SetProjection(webMercatorWKT)
+	buf := make([]uint8, 64*64)
+	for i := range buf {
+		buf[i] = uint8(i % 64)
+	}
+	assert.NoError(t, src.RasterBand(1).IO(gdal.Write, 0, 0, 64, 64, buf, 64, 64, 0, 0))
+
+	dst := driver.Create("", 64, 64, 1, gdal.Byte, nil)
+	dst.SetGeoTransform([6]float64{0, 1, 0, 64, 0, -1})
+	dst.SetProjection(webMercatorWKT)
+	return src, dst
+}
+
+func TestWarpOperationGenImgProj(t *testing.T) {
+	src, dst := warpTestDatasets(t)
+	defer src.Close()
+	defer dst.Close()
+
+	tr, err := gdal.CreateGenImgProjTransformer2(src, dst, nil)
+	assert.NoError(t, err)
+	defer tr.Destroy()
+
+	opts := gdal.CreateWarpOptions()
+	defer opts.Destroy()
+	opts.SetSourceDataset(src)
+	opts.SetDestinationDataset(dst)
+	assert.NoError(t, opts.SetBands([]int{1}, []int{1}))
+	assert.NoError(t, opts.SetSourceNoData([]float64{255}))
+	assert.Error(t, opts.SetDestinationNoData([]float64{0, 0}))
+	opts.SetNumThreads(2)
+	assert.Equal(t, "2", opts.WarpOption("NUM_THREADS"))
+	opts.SetMemoryLimit(1 << 20)
+	opts.SetTransformer(tr)
+
+	op, err := gdal.CreateWarpOperation(opts)
+	assert.NoError(t, err)
+	defer op.Destroy()
+
+	calls := 0
+	progress := func(complete float64, message string, data interface{}) int {
+		calls++
+		return 1
+	}
+	assert.NoError(t, op.ChunkAndWarpImage(context.Background(), 0, 0, 64, 64, progress, nil))
+	assert.Greater(t, calls, 0)
+
+	out := make([]uint8, 64*64)
+	assert.NoError(t, dst.RasterBand(1).IO(gdal.Read, 0, 0, 64, 64, out, 64, 64, 0, 0))
+	assert.Equal(t, uint8(10), out[10])
+	assert.Equal(t, uint8(63), out[64*64-1])
+}
+
+func TestWarpOperationGoTransformer(t *testing.T) {
+	src, dst := warpTestDatasets(t)
+	defer src.Close()
+	defer dst.Close()
+
+	opts := gdal.CreateWarpOptions()
+	defer opts.Destroy()
+	opts.SetSourceDataset(src)
+	opts.SetDestinationDataset(dst)
+	assert.NoError(t, opts.SetBands([]int{1}, []int{1}))
+	opts.SetTransformer(shiftTransformer{dx: 10})
+
+	op, err := gdal.CreateWarpOperation(opts)
+	assert.NoError(t, err)
+	defer op.Destroy()
+
+	assert.NoError(t, op.WarpRegion(context.Background(), 0, 0, 32, 32, 10, 0, 32, 32, nil, nil))
+
+	out := make([]uint8, 32)
+	assert.NoError(t, dst.RasterBand(1).IO(gdal.Read, 0, 0, 32, 1, out, 32, 1, 0, 0))
+	assert.Equal(t, uint8(10), out[0])
+	assert.Equal(t, uint8(20), out[10])
+}
+
+func TestWarpOperationGoTransformerThreads(t *testing.T) {
+	src, dst := warpTestDatasets(t)
+	defer src.Close()
+	defer dst.Close()
+
+	opts := gdal.CreateWarpOptions()
+	defer opts.Destroy()
+	opts.SetSourceDataset(src)
+	opts.SetDestinationDataset(dst)
+	assert.NoError(t, opts.SetBands([]int{1}, []int{1}))
+	opts.SetTransformer(shiftTransformer{dx: 10})
+	opts.SetNumThreads(2)
+
+	_, err := gdal.CreateWarpOperation(opts)
+	assert.Error(t, err)
+
+	opts.SetWarpOption("NUM_THREADS", "1")
+	op, err := gdal.CreateWarpOperation(opts)
+	assert.NoError(t, err)
+	defer op.Destroy()
+	assert.NoError(t, op.ChunkAndWarpImage(context.Background(), 0, 0, 64, 64, nil, nil))
+
+	out := make([]uint8, 64)
+	assert.NoError(t, dst.RasterBand(1).IO(gdal.Read, 0, 0, 64, 1, out, 64, 1, 0, 0))
+	assert.Equal(t, uint8(10), out[0])
+}
+
+func TestWarpOperationCancel(t *testing.T) {
+	src, dst := warpTestDatasets(t)
+	defer src.Close()
+	defer dst.Close()
+
+	opts := gdal.CreateWarpOptions()
+	defer opts.Destroy()
+	opts.SetSourceDataset(src)
+	opts.SetDestinationDataset(dst)
+	assert.NoError(t, opts.SetBands([]int{1}, []int{1}))
+	opts.SetTransformer(shiftTransformer{})
+
+	op, err := gdal.CreateWarpOperation(opts)
+	assert.NoError(t, err)
+	defer op.Destroy()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, op.ChunkAndWarpImage(ctx, 0, 0, 64, 64, nil, nil), context.Canceled)
+
+	// cancel once GDAL reports progress, small chunks leaving the rest of the destination unwritten
+	opts.SetMemoryLimit(1024)
+	chunked, err := gdal.CreateWarpOperation(opts)
+	assert.NoError(t, err)
+	defer chunked.Destroy()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	progress := func(complete float64, message string, data interface{}) int {
+		calls++
+		cancel()
+		return 1
+	}
+	assert.ErrorIs(t, chunked.ChunkAndWarpImage(ctx, 0, 0, 64, 64, progress, nil), context.Canceled)
+	assert.Equal(t, 1, calls)
+
+	out := make([]uint8, 64*64)
+	assert.NoError(t, dst.RasterBand(1).IO(gdal.Read, 0, 0, 64, 64, out, 64, 64, 0, 0))
+	unwritten := 0
+	for i, v := range out {
+		if v != uint8(i%64) {
+			unwritten++
+		}
+	}
+	assert.Greater(t, unwritten, 0)
+
+	empty := gdal.CreateWarpOptions()
+	defer empty.Destroy()
+	_, err = gdal.CreateWarpOperation(empty)
+	assert.Error(t, err)
+}